@@ -0,0 +1,165 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package artifacts
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job      string
+	branch   string
+	glob     string
+	dest     string
+	download bool
+	parallel int
+	force    bool
+)
+
+// ArtifactsCmd represents the artifacts command
+var ArtifactsCmd = &cobra.Command{
+	Use:   "artifacts [build]",
+	Short: "List and download the artifacts of a build",
+	Long: `List the archived artifacts of a build of the current branch's job,
+defaulting to the last build. With --download or --dest, the matching
+artifacts are downloaded concurrently.
+
+Files already present in the destination are skipped unless --force is
+given, and interrupted downloads are resumed. Artifacts are verified against
+the MD5 fingerprints Jenkins recorded for them, when available; files of
+artifacts without a fingerprint are only skipped or resumed if their size
+and modification time match the artifact on Jenkins.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return artifacts(cmd, build)
+	},
+}
+
+func init() {
+	ArtifactsCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	ArtifactsCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch of the build (default: current git branch)")
+	ArtifactsCmd.Flags().StringVarP(&glob, "glob", "g", "", "Only include artifacts matching the pattern, e.g. 'dist/**/*.tar.gz'")
+	ArtifactsCmd.Flags().StringVar(&dest, "dest", ".", "Directory to download artifacts to")
+	ArtifactsCmd.Flags().BoolVarP(&download, "download", "d", false, "Download the matching artifacts")
+	ArtifactsCmd.Flags().IntVar(&parallel, "parallel", 4, "Number of artifacts to download at a time")
+	ArtifactsCmd.Flags().BoolVar(&force, "force", false, "Download artifacts even if already present")
+}
+
+func artifacts(cmd *cobra.Command, build string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	// Pin the build number so all downloads come from the same build
+	status, err := client.GetBuildStatus(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+	build = strconv.FormatInt(status.Number, 10)
+
+	all, err := client.ListArtifacts(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	var matched []jenkins.Artifact
+	for _, a := range all {
+		if glob == "" || utils.MatchGlob(glob, a.RelativePath) {
+			matched = append(matched, a)
+		}
+	}
+
+	if !download && !cmd.Flags().Changed("dest") {
+		return pr.Print(artifactList(matched))
+	}
+
+	if len(matched) == 0 {
+		return errs.New(errs.KindNotFound, "No artifacts of %s #%s match '%s'", branchJob, build, glob)
+	}
+
+	return downloadAll(cmd, &streams, client, branchJob, build, matched)
+}
+
+type artifactList []jenkins.Artifact
+
+func (l artifactList) Header() []string {
+	return []string{"PATH", "FINGERPRINT"}
+}
+
+func (l artifactList) Rows() [][]string {
+	rows := make([][]string, len(l))
+	for i, a := range l {
+		rows[i] = []string{a.RelativePath, a.Hash}
+	}
+
+	return rows
+}
+
+// destPath returns the local path of an artifact, refusing paths which
+// would escape the destination directory
+func destPath(a jenkins.Artifact) (string, error) {
+	path := filepath.Join(dest, filepath.FromSlash(a.RelativePath))
+
+	if rel, err := filepath.Rel(dest, path); err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", errs.New(errs.KindValidation, "Refusing to download artifact with unsafe path %s", a.RelativePath)
+	}
+
+	return path, nil
+}
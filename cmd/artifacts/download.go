@@ -0,0 +1,218 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package artifacts
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// progressInterval is how often the progress line is redrawn
+const progressInterval = 200 * time.Millisecond
+
+// downloadAll downloads the artifacts with a pool of parallel workers
+func downloadAll(cmd *cobra.Command, streams *utils.IOStreams, client *jenkins.Client, job string, build string, artifacts []jenkins.Artifact) error {
+	if parallel < 1 {
+		parallel = 1
+	}
+
+	progress := newProgress(streams, len(artifacts))
+
+	jobs := make(chan jenkins.Artifact)
+	errs := make(chan error, len(artifacts))
+
+	var wg sync.WaitGroup
+	for i := 0; i < parallel; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for a := range jobs {
+				errs <- downloadOne(cmd, client, job, build, a, progress)
+			}
+		}()
+	}
+
+	for _, a := range artifacts {
+		jobs <- a
+	}
+	close(jobs)
+
+	wg.Wait()
+	close(errs)
+	progress.finish()
+
+	failed := 0
+	for err := range errs {
+		if err != nil {
+			fmt.Fprintln(streams.ErrOut, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d artifacts failed to download", failed, len(artifacts))
+	}
+
+	return nil
+}
+
+func downloadOne(cmd *cobra.Command, client *jenkins.Client, job string, build string, a jenkins.Artifact, progress *progress) error {
+	path, err := destPath(a)
+	if err != nil {
+		return err
+	}
+
+	if !force {
+		current, err := client.IsArtifactCurrent(cmd.Context(), job, build, a, path)
+		if err != nil {
+			return err
+		}
+
+		if current {
+			progress.done(a.RelativePath, "up to date")
+			return nil
+		}
+	}
+
+	if force {
+		os.Remove(path + ".part")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	err = client.DownloadArtifact(cmd.Context(), job, build, a, path, func(downloaded int64, total int64) {
+		progress.update(a.RelativePath, downloaded, total)
+	})
+	if err != nil {
+		return err
+	}
+
+	status := "downloaded"
+	if a.Hash != "" {
+		status = "downloaded, fingerprint verified"
+	}
+	progress.done(a.RelativePath, status)
+
+	return nil
+}
+
+// progress reports the downloads in progress. On a terminal a status line
+// is redrawn with the overall progress; otherwise only finished files are
+// reported.
+type progress struct {
+	mu       sync.Mutex
+	out      io.Writer
+	tty      bool
+	files    int
+	finished int
+	sizes    map[string][2]int64
+	drawn    time.Time
+}
+
+func newProgress(streams *utils.IOStreams, files int) *progress {
+	tty := false
+	if f, ok := streams.ErrOut.(*os.File); ok {
+		tty = term.IsTerminal(int(f.Fd()))
+	}
+
+	return &progress{
+		out:   streams.ErrOut,
+		tty:   tty,
+		files: files,
+		sizes: map[string][2]int64{},
+	}
+}
+
+func (p *progress) update(name string, downloaded int64, total int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.sizes[name] = [2]int64{downloaded, total}
+
+	if p.tty && time.Since(p.drawn) >= progressInterval {
+		p.draw()
+	}
+}
+
+func (p *progress) done(name string, status string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.finished++
+	delete(p.sizes, name)
+
+	if p.tty {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+	fmt.Fprintf(p.out, "%s (%s)\n", name, status)
+
+	if p.tty {
+		p.draw()
+	}
+}
+
+func (p *progress) finish() {
+	if p.tty {
+		fmt.Fprint(p.out, "\r\033[K")
+	}
+}
+
+// draw redraws the status line, the caller must hold the lock
+func (p *progress) draw() {
+	var downloaded, total int64
+	for _, s := range p.sizes {
+		downloaded += s[0]
+		if s[1] > 0 {
+			total += s[1]
+		}
+	}
+
+	fmt.Fprintf(p.out, "\r\033[KDownloaded %d/%d files, %s of %s in progress", p.finished, p.files, formatBytes(downloaded), formatBytes(total))
+	p.drawn = time.Now()
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}
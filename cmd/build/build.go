@@ -0,0 +1,245 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package build
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	branch      string
+	params      []string
+	wait        bool
+	interactive bool
+)
+
+// Exit codes of a build started with --wait
+var resultExitCodes = map[string]int{
+	jenkins.ResultSuccess:  0,
+	jenkins.ResultFailure:  1,
+	jenkins.ResultUnstable: 2,
+	jenkins.ResultAborted:  3,
+}
+
+// BuildCmd represents the build command
+var BuildCmd = &cobra.Command{
+	Use:   "build [job]",
+	Short: "Trigger a build of a Jenkins job",
+	Long: `Trigger a build of a Jenkins job, defaulting to the current branch's job.
+
+Parameters are validated against the job's parameter definitions. Values of
+file parameters are paths of local files to upload. The default parameters
+from the project file are used when building the current branch's job.
+
+With --interactive, each parameter is prompted for, with the values given by
+-p or the job's defaults pre-filled.
+
+With --wait, the command waits for the build to finish and exits with:
+  0  SUCCESS
+  1  FAILURE (or NOT_BUILT)
+  2  UNSTABLE
+  3  ABORTED`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := build(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if code := ExitCode(result); wait && code != 0 {
+			return &errs.ExitError{Code: code}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	BuildCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to build (default: current git branch)")
+	BuildCmd.Flags().StringArrayVarP(&params, "param", "p", nil, "Build parameter as key=value (repeatable)")
+	BuildCmd.Flags().BoolVarP(&wait, "wait", "w", false, "Wait for the build to finish and exit with its result")
+	BuildCmd.Flags().BoolVarP(&interactive, "interactive", "i", false, "Prompt for each build parameter")
+}
+
+func build(cmd *cobra.Command, args []string) (string, error) {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return "", err
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{}
+
+	var job string
+	if len(args) == 1 {
+		job = args[0]
+	} else {
+		if job, err = p.BranchJob(cmd.Context(), &streams, "", branch); err != nil {
+			return "", err
+		}
+
+		for k, v := range p.Parameters {
+			values[k] = v
+		}
+	}
+
+	if err := ParseParams(params, values); err != nil {
+		return "", err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return "", err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return "", err
+	}
+
+	defs, err := client.GetParameterDefinitions(cmd.Context(), job)
+	if err != nil {
+		return "", err
+	}
+
+	if interactive {
+		if values, err = PromptParameters(&streams, defs, values); err != nil {
+			return "", err
+		}
+	}
+
+	if err := jenkins.ValidateParameters(defs, values); err != nil {
+		return "", err
+	}
+
+	return Trigger(cmd, pr, client, job, defs, values, wait)
+}
+
+// ParseParams adds key=value parameters to values
+func ParseParams(params []string, values map[string]string) error {
+	for _, param := range params {
+		k, v, ok := strings.Cut(param, "=")
+		if !ok {
+			return errs.New(errs.KindValidation, "Invalid parameter '%s', expected key=value", param)
+		}
+		values[k] = v
+	}
+
+	return nil
+}
+
+// ExitCode returns the exit code for the result of a build
+func ExitCode(result string) int {
+	if code, ok := resultExitCodes[result]; ok {
+		return code
+	}
+
+	return 1
+}
+
+// triggered is a build started by Trigger, as printed in structured formats
+type triggered struct {
+	Job       string `json:"job"`
+	QueueItem int64  `json:"queueItem"`
+	Build     int64  `json:"build"`
+	Url       string `json:"url"`
+	Result    string `json:"result,omitempty"`
+}
+
+// Trigger queues a build and follows it until it starts, or finishes if
+// wait is set. It returns the result of the finished build. Progress is
+// printed as it happens, while structured formats print the build once
+// Trigger is done following it.
+func Trigger(cmd *cobra.Command, pr *printer.Printer, client *jenkins.Client, job string, defs []jenkins.ParameterDefinition, values map[string]string, wait bool) (string, error) {
+	streams := utils.CommandStreams(cmd)
+
+	id, err := client.TriggerBuild(cmd.Context(), job, defs, values)
+	if err != nil {
+		return "", err
+	}
+
+	if !pr.IsStructured() {
+		fmt.Fprintf(streams.Out, "Queued %s (queue item %d)\n", job, id)
+	}
+
+	number, err := client.WaitForQueueItem(cmd.Context(), id)
+	if err != nil {
+		return "", err
+	}
+
+	status, err := client.GetBuildStatus(cmd.Context(), job, fmt.Sprint(number))
+	if err != nil {
+		return "", err
+	}
+
+	result := triggered{Job: job, QueueItem: id, Build: number, Url: status.Url}
+
+	if !pr.IsStructured() {
+		fmt.Fprintf(streams.Out, "Started %s #%d %s\n", job, number, status.Url)
+	}
+
+	if wait {
+		if status, err = client.WaitForBuild(cmd.Context(), job, number); err != nil {
+			return "", err
+		}
+		result.Result = status.Result
+	}
+
+	if pr.IsStructured() {
+		return result.Result, pr.Print(result)
+	}
+
+	if wait {
+		fmt.Fprintln(streams.Out, "Finished:", resultText(result.Result))
+	}
+
+	return result.Result, nil
+}
+
+func resultText(result string) string {
+	switch result {
+	case jenkins.ResultSuccess:
+		return color.GreenString(result)
+	case jenkins.ResultUnstable:
+		return color.YellowString(result)
+	case jenkins.ResultFailure:
+		return color.RedString(result)
+	default:
+		return result
+	}
+}
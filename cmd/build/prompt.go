@@ -0,0 +1,88 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package build
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/fatih/color"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/prompt"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// PromptParameters asks for a value of each parameter, pre-filled with the
+// given values or the parameter defaults, re-asking until the value is valid.
+func PromptParameters(streams *utils.IOStreams, defs []jenkins.ParameterDefinition, values map[string]string) (map[string]string, error) {
+	p := prompt.New(streams)
+	result := map[string]string{}
+
+	for k, v := range values {
+		result[k] = v
+	}
+
+	for _, d := range defs {
+		def, ok := values[d.Name]
+		if !ok {
+			def = d.Default()
+		}
+
+		if d.Description != "" {
+			fmt.Fprintln(streams.ErrOut, color.CyanString(d.Description))
+		}
+
+		for {
+			value, err := promptParameter(p, d, def)
+			if err != nil {
+				return nil, err
+			}
+
+			if err := d.Validate(value); err != nil {
+				fmt.Fprintln(streams.ErrOut, err)
+				continue
+			}
+
+			result[d.Name] = value
+			break
+		}
+	}
+
+	return result, nil
+}
+
+func promptParameter(p *prompt.Prompter, d jenkins.ParameterDefinition, def string) (string, error) {
+	switch d.Kind() {
+	case jenkins.ParamChoice:
+		return p.Select(d.Name, d.Choices, def)
+	case jenkins.ParamBoolean:
+		b, err := p.Confirm(d.Name, def == "true")
+		return strconv.FormatBool(b), err
+	case jenkins.ParamPassword:
+		return p.Password(d.Name, def)
+	case jenkins.ParamFile:
+		return p.Input(d.Name+" (file path)", def)
+	default:
+		return p.Input(d.Name, def)
+	}
+}
@@ -0,0 +1,213 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package builds
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// pageSize is how many builds are requested at a time
+const pageSize = 50
+
+var (
+	branch string
+	result string
+	since  time.Duration
+	limit  int
+)
+
+// buildRow is a build as printed by the builds command
+type buildRow struct {
+	Number   int64     `json:"number"`
+	Result   string    `json:"result"`
+	Duration string    `json:"duration"`
+	Started  time.Time `json:"started"`
+	Cause    string    `json:"cause"`
+	User     string    `json:"user"`
+}
+
+// BuildsCmd represents the builds command
+var BuildsCmd = &cobra.Command{
+	Use:   "builds [job]",
+	Short: "List recent builds of a Jenkins job",
+	Long: `List recent builds of a Jenkins job, defaulting to the current branch's job.
+
+Builds are listed newest first with their result, duration, start time, cause
+and triggering user. Besides the global output formats, --output csv prints
+the builds as CSV.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return builds(cmd, args)
+	},
+}
+
+func init() {
+	BuildsCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch whose job is listed (default: current git branch)")
+	BuildsCmd.Flags().StringVar(&result, "result", "", "Only list builds with this result, e.g. failure or running")
+	BuildsCmd.Flags().DurationVar(&since, "since", 0, "Only list builds started within this duration, e.g. 24h")
+	BuildsCmd.Flags().IntVar(&limit, "limit", 20, "Maximum number of builds to list")
+}
+
+func builds(cmd *cobra.Command, args []string) error {
+	if limit < 1 {
+		return errs.New(errs.KindValidation, "The limit option must be at least 1, got %d", limit)
+	}
+
+	streams := utils.CommandStreams(cmd)
+	output := cmd.Flag("output").Value.String()
+
+	var pr *printer.Printer
+	if output != "csv" {
+		var err error
+		if pr, err = printer.FromCommand(cmd, &streams); err != nil {
+			return err
+		}
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	var job string
+	if len(args) == 1 {
+		job = args[0]
+	} else if job, err = p.BranchJob(cmd.Context(), &streams, "", branch); err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	rows, err := listBuilds(cmd, client, job)
+	if err != nil {
+		return err
+	}
+
+	if pr == nil {
+		return printCSV(streams.Out, rows)
+	}
+
+	return pr.Print(rows)
+}
+
+// listBuilds pages through the builds of the job, newest first, until
+// enough builds match the filters or the builds are older than --since.
+func listBuilds(cmd *cobra.Command, client *jenkins.Client, job string) (buildList, error) {
+	now := time.Now()
+	rows := buildList{}
+
+	for start := 0; len(rows) < limit; start += pageSize {
+		page, err := client.ListBuilds(cmd.Context(), job, start, start+pageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, b := range page {
+			if since > 0 && now.Sub(b.StartTime()) > since {
+				return rows, nil
+			}
+
+			if result != "" && !strings.EqualFold(b.Status(), result) {
+				continue
+			}
+
+			rows = append(rows, newBuildRow(b, now))
+			if len(rows) == limit {
+				break
+			}
+		}
+
+		if len(page) < pageSize {
+			break
+		}
+	}
+
+	return rows, nil
+}
+
+func newBuildRow(b jenkins.BuildSummary, now time.Time) buildRow {
+	row := buildRow{
+		Number:   b.Number,
+		Result:   b.Status(),
+		Duration: b.Elapsed(now).Round(time.Second).String(),
+		Started:  b.StartTime(),
+	}
+
+	var causes, users []string
+	for _, c := range b.Causes() {
+		causes = append(causes, c.ShortDescription)
+		if c.UserId != "" {
+			users = append(users, c.UserId)
+		}
+	}
+
+	row.Cause = strings.Join(causes, "; ")
+	row.User = strings.Join(users, ", ")
+
+	return row
+}
+
+type buildList []buildRow
+
+func (l buildList) Header() []string {
+	return []string{"BUILD", "RESULT", "DURATION", "STARTED", "CAUSE", "USER"}
+}
+
+func (l buildList) Rows() [][]string {
+	rows := make([][]string, len(l))
+	for i, r := range l {
+		rows[i] = []string{fmt.Sprintf("#%d", r.Number), r.Result, r.Duration, r.Started.Format("2006-01-02 15:04"), r.Cause, r.User}
+	}
+
+	return rows
+}
+
+func printCSV(out io.Writer, rows buildList) error {
+	w := csv.NewWriter(out)
+	w.Write([]string{"number", "result", "duration", "started", "cause", "user"})
+	for _, r := range rows {
+		w.Write([]string{fmt.Sprint(r.Number), r.Result, r.Duration, r.Started.Format(time.RFC3339), r.Cause, r.User})
+	}
+	w.Flush()
+
+	return w.Error()
+}
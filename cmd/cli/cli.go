@@ -0,0 +1,67 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+)
+
+var CliCmd = &cobra.Command{
+	Use:   "cli",
+	Short: "Manage the Jenkins CLI jar",
+	Long: `Manage the Jenkins CLI jar used by the jar transport.
+
+Jars are shared by all contexts using the same Jenkins version, and their
+SHA-256 checksums are recorded when downloaded. The jar transport downloads
+the jar again when it is missing or its version differs from the server's.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+// getContext returns the named context, or the current context if no name is given
+func getContext(args []string) (config.Context, error) {
+	if len(args) == 1 {
+		cfg, err := config.ReadConfig()
+		if err != nil {
+			return config.Context{}, err
+		}
+
+		return cfg.GetContext(args[0])
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return config.Context{}, err
+	}
+
+	return p.CurrentContext()
+}
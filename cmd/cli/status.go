@@ -0,0 +1,132 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// statusCmd represents the status command
+var statusCmd = &cobra.Command{
+	Use:   "status [context]",
+	Short: "Show the Jenkins CLI jar version compared to the server",
+	Long:  `Show the path, version and checksum status of the Jenkins CLI jar, and whether it matches the Jenkins server version.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return status(cmd, args)
+	},
+}
+
+// cliStatus is the state of the CLI jar printed by the status command
+type cliStatus struct {
+	Context       string `json:"context"`
+	Path          string `json:"path"`
+	Present       bool   `json:"present"`
+	Version       string `json:"version,omitempty"`
+	Verified      bool   `json:"verified"`
+	ChecksumError string `json:"checksumError,omitempty"`
+	ServerVersion string `json:"serverVersion,omitempty"`
+	UpToDate      bool   `json:"upToDate"`
+}
+
+func status(cmd *cobra.Command, args []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := getContext(args)
+	if err != nil {
+		return err
+	}
+
+	cli := jenkins.NewJenkinsCli(&ctx, &streams)
+
+	path, err := cli.GetCliPath()
+	if err != nil {
+		return err
+	}
+
+	s := cliStatus{Context: ctx.Name, Path: path}
+
+	if _, err := os.Stat(path); err == nil {
+		s.Present = true
+
+		if s.Version, err = cli.Version(); err != nil {
+			return err
+		}
+
+		if err := cli.Verify(); err != nil {
+			s.ChecksumError = err.Error()
+		} else {
+			s.Verified = true
+		}
+
+		if s.ServerVersion, err = cli.ServerVersion(cmd.Context()); err != nil {
+			return err
+		}
+
+		s.UpToDate = s.Version == s.ServerVersion
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(s)
+	}
+
+	fmt.Fprintln(streams.Out, "Context:", s.Context)
+	fmt.Fprintln(streams.Out, "CLI path:", s.Path)
+
+	if !s.Present {
+		fmt.Fprintln(streams.Out, color.YellowString("CLI jar not present. Run 'jenkinsw cli update' to download it."))
+		return nil
+	}
+
+	fmt.Fprintln(streams.Out, "CLI version:", s.Version)
+
+	if s.Verified {
+		fmt.Fprintln(streams.Out, "Checksum:", color.GreenString("verified"))
+	} else {
+		fmt.Fprintln(streams.Out, "Checksum:", color.RedString(s.ChecksumError))
+	}
+
+	fmt.Fprintln(streams.Out, "Server version:", s.ServerVersion)
+
+	if !s.UpToDate {
+		fmt.Fprintln(streams.Out, color.YellowString("CLI jar is out of date. Run 'jenkinsw cli update' to update it."))
+	}
+
+	return nil
+}
+
+func init() {
+	CliCmd.AddCommand(statusCmd)
+}
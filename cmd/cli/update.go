@@ -0,0 +1,104 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var force bool
+
+// updateCmd represents the update command
+var updateCmd = &cobra.Command{
+	Use:   "update [context]",
+	Short: "Download the Jenkins CLI jar if it does not match the server version",
+	Long: `Download the Jenkins CLI jar from the Jenkins server if it is missing, does not
+match the server version, or fails checksum verification.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return update(cmd, args)
+	},
+}
+
+// updateResult is the CLI jar version printed by the update command
+type updateResult struct {
+	Version string `json:"version"`
+	Updated bool   `json:"updated"`
+}
+
+func update(cmd *cobra.Command, args []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := getContext(args)
+	if err != nil {
+		return err
+	}
+
+	cli := jenkins.NewJenkinsCli(&ctx, &streams)
+
+	if !force {
+		serverVersion, err := cli.ServerVersion(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if cliVersion, err := cli.Version(); err == nil && cliVersion == serverVersion && cli.Verify() == nil {
+			if pr.IsStructured() {
+				return pr.Print(updateResult{Version: cliVersion})
+			}
+
+			fmt.Fprintf(streams.Out, "Jenkins CLI %s is up to date\n", cliVersion)
+			return nil
+		}
+	}
+
+	if err := cli.DownloadCliJar(cmd.Context()); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		cliVersion, err := cli.Version()
+		if err != nil {
+			return err
+		}
+
+		return pr.Print(updateResult{Version: cliVersion, Updated: true})
+	}
+
+	return nil
+}
+
+func init() {
+	updateCmd.Flags().BoolVarP(&force, "force", "f", false, "Download the jar even if it is up to date")
+	CliCmd.AddCommand(updateCmd)
+}
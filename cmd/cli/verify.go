@@ -0,0 +1,74 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// verifyCmd represents the verify command
+var verifyCmd = &cobra.Command{
+	Use:   "verify [context]",
+	Short: "Verify the Jenkins CLI jar against its recorded checksum",
+	Long:  `Verify the Jenkins CLI jar against the SHA-256 checksum recorded when it was downloaded.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		streams := utils.CommandStreams(cmd)
+
+		pr, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		ctx, err := getContext(args)
+		if err != nil {
+			return err
+		}
+
+		cli := jenkins.NewJenkinsCli(&ctx, &streams)
+
+		if err := cli.Verify(); err != nil {
+			return err
+		}
+
+		path, _ := cli.GetCliPath()
+		if pr.IsStructured() {
+			return pr.Print(struct {
+				Path string `json:"path"`
+			}{path})
+		}
+
+		fmt.Fprintln(streams.Out, "Verified", path)
+
+		return nil
+	},
+}
+
+func init() {
+	CliCmd.AddCommand(verifyCmd)
+}
@@ -23,61 +23,213 @@ package context
 
 import (
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/prompt"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	addName       string
+	addHost       string
+	addUsername   string
+	addTokenStdin bool
+	addTokenEnv   string
+	addTest       bool
+	addStore      string
+	addHelper     string
 )
 
 var contextAddCmd = &cobra.Command{
 	Use:   "add",
 	Short: "Add a Jenkins context",
-	Long:  `Add a new Jenkins context configuration, by providing flag parameters or interactively.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		name, _ := prompt("Context name: ")
-		host, _ := prompt("Jenkins URL: ")
+	Long: `Add a new Jenkins context configuration, by providing flag parameters or interactively.
 
-		// Prompt for username + API token, store in secure file
-		username, _ := prompt("Jenkins username: ")
-		apiToken, _ := prompt("Jenkins API token: ")
+Values missing from the flags are prompted for. The API token is read from
+stdin with --token-stdin, from an environment variable with --token-env, or
+prompted for without echoing it. The new context becomes the current context.
 
-		cfg, err := config.ReadConfig()
-		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+The token is kept in the secret store chosen with --secret-store: the config
+file (plain, the default), a file encrypted with a passphrase (file), or an
+external credential helper speaking the Docker credential helper protocol
+(helper). The passphrase is read from $JENKINSW_PASSPHRASE or prompted for.
+
+  jenkinsw context add --name ci --host jenkins.example.com --username me --token-env JENKINS_TOKEN
+  echo "$TOKEN" | jenkinsw context add --name ci --host https://jenkins.example.com --username me --token-stdin --test
+  jenkinsw context add --name ci --secret-store helper --credential-helper docker-credential-secretservice`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return add(cmd)
+	},
+}
+
+func init() {
+	contextAddCmd.Flags().StringVar(&addName, "name", "", "Name of the context")
+	contextAddCmd.Flags().StringVar(&addHost, "host", "", "Jenkins URL, defaulting to https if no scheme is given")
+	contextAddCmd.Flags().StringVar(&addUsername, "username", "", "Jenkins username")
+	contextAddCmd.Flags().BoolVar(&addTokenStdin, "token-stdin", false, "Read the API token from stdin")
+	contextAddCmd.Flags().StringVar(&addTokenEnv, "token-env", "", "Read the API token from this environment variable")
+	contextAddCmd.Flags().BoolVar(&addTest, "test", false, "Test the connection to Jenkins before saving the context")
+	contextAddCmd.Flags().StringVar(&addStore, "secret-store", config.SecretStorePlain, "Secret store of the API token: "+strings.Join(config.SecretStores, ", "))
+	contextAddCmd.Flags().StringVar(&addHelper, "credential-helper", "", "Credential helper command of the helper secret store")
+
+	ContextCmd.AddCommand(contextAddCmd)
+}
+
+func add(cmd *cobra.Command) error {
+	if addTokenStdin && addTokenEnv != "" {
+		return errs.New(errs.KindValidation, "The token-stdin and token-env options cannot be combined")
+	}
+
+	streams := utils.CommandStreams(cmd)
+	p := prompt.New(&streams)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	store := config.Context{Name: addName, SecretStore: addStore, CredentialHelper: addHelper}
+	if _, err := store.GetSecretStore(); err != nil {
+		return errs.Wrap(errs.KindValidation, err)
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	name, err := ask(p, &streams, "Context name", "name", addName, func(name string) (string, error) {
+		if err := config.ValidateName(name); err != nil {
+			return "", err
+		}
+		if cfg.IsExistingContext(name) {
+			return "", errs.New(errs.KindValidation, "Context named '%s' already exists", name)
 		}
+		return name, nil
+	})
+	if err != nil {
+		return err
+	}
 
-		context := config.Context{name, host, username, apiToken}
-		cfg.Contexts = append(cfg.Contexts, context)
-		cfg.CurrentContext = name
+	host, err := ask(p, &streams, "Jenkins URL", "host", addHost, config.NormalizeHost)
+	if err != nil {
+		return err
+	}
 
-		err = cfg.Save()
-		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+	username, err := ask(p, &streams, "Jenkins username", "username", addUsername, func(username string) (string, error) {
+		username = strings.TrimSpace(username)
+		if username == "" {
+			return "", errs.New(errs.KindValidation, "The username cannot be empty")
 		}
+		return username, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	apiToken, err := readToken(p, &streams)
+	if err != nil {
+		return err
+	}
 
-		err = context.SaveAuthFile()
+	context := config.Context{Name: name, Host: host, Username: username, SecretStore: addStore, CredentialHelper: addHelper}
+
+	if addTest {
+		probe := config.Context{Name: name, Host: host, Username: username, ApiToken: apiToken}
+		client, err := jenkins.NewClient(cmd.Context(), &probe, &streams)
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return fmt.Errorf("connection test failed: %w", err)
 		}
-	},
+		if !pr.IsStructured() {
+			fmt.Fprintf(streams.Out, "Connected to %s (Jenkins %s)\n", host, client.Version())
+		}
+	}
+
+	if err := context.SetToken(apiToken); err != nil {
+		return err
+	}
+
+	if err := cfg.AddContext(context); err != nil {
+		return err
+	}
+	cfg.CurrentContext = name
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		// The API token is never shown
+		context.ApiToken = ""
+		return pr.Print(context)
+	}
+
+	fmt.Fprintf(streams.Out, "Added context %s and switched to it\n", name)
+
+	return nil
 }
 
-func init() {
-	ContextCmd.AddCommand(contextAddCmd)
+// ask returns the validated flag value if set, or prompts for a value until
+// a valid one is given. Nothing is prompted for when stdin holds the token.
+func ask(p *prompt.Prompter, streams *utils.IOStreams, label string, flag string, value string, validate func(string) (string, error)) (string, error) {
+	if value != "" {
+		return validate(value)
+	}
+
+	if addTokenStdin {
+		return "", errs.New(errs.KindValidation, "The %s option is required with token-stdin", flag)
+	}
+
+	for {
+		answer, err := p.Required(label)
+		if err != nil {
+			return "", err
+		}
+
+		value, err := validate(answer)
+		if err == nil || !p.IsInteractive() {
+			return value, err
+		}
+
+		fmt.Fprintln(streams.ErrOut, err)
+	}
 }
 
-func prompt(text string) (string, error) {
-	fmt.Print(text)
-	var result string
-	_, err := fmt.Scanln(&result)
-	if err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+// readToken reads the API token from stdin, the environment or a hidden prompt
+func readToken(p *prompt.Prompter, streams *utils.IOStreams) (string, error) {
+	var token string
+
+	switch {
+	case addTokenStdin:
+		b, err := io.ReadAll(streams.In)
+		if err != nil {
+			return "", err
+		}
+		token = strings.TrimSpace(string(b))
+	case addTokenEnv != "":
+		token = strings.TrimSpace(os.Getenv(addTokenEnv))
+		if token == "" {
+			return "", errs.New(errs.KindValidation, "Environment variable %s is not set", addTokenEnv)
+		}
+	default:
+		var err error
+		if token, err = p.Password("Jenkins API token", ""); err != nil {
+			return "", err
+		}
+	}
+
+	if token == "" {
+		return "", errs.New(errs.KindValidation, "API token is required")
 	}
 
-	return result, nil
+	return token, nil
 }
@@ -0,0 +1,112 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// copyCmd represents the copy command
+var copyCmd = &cobra.Command{
+	Use:   "copy <context> <new-name>",
+	Short: "Copy a Jenkins context",
+	Long: `Copy a Jenkins context to a new name, along with its API token and cached data.
+
+Change the copy afterwards with 'jenkinsw context set'.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return copyContext(cmd, args[0], args[1])
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(copyCmd)
+}
+
+func copyContext(cmd *cobra.Command, name string, newName string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	context, err := cfg.GetContext(name)
+	if err != nil {
+		return err
+	}
+
+	copied := context
+	copied.Name = newName
+
+	if err := config.ValidateName(newName); err != nil {
+		return err
+	}
+
+	if cfg.IsExistingContext(newName) {
+		return errs.New(errs.KindValidation, "Context named '%s' already exists", newName)
+	}
+
+	if err := copied.RemoveContextDir(); err != nil {
+		return err
+	}
+
+	if err := context.CopyContextDir(copied); err != nil {
+		return err
+	}
+
+	if err := context.CopySecret(&copied); err != nil {
+		return err
+	}
+
+	if err := cfg.AddContext(copied); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		copied.RemoveContextDir()
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Context    string `json:"context"`
+			CopiedFrom string `json:"copiedFrom"`
+		}{newName, name})
+	}
+
+	fmt.Fprintf(streams.Out, "Copied context %s to %s\n", name, newName)
+
+	return nil
+}
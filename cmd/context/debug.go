@@ -23,18 +23,22 @@ package context
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
 	"github.com/alecthomas/chroma/v2/quick"
 	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
 	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
-func PrintConfigDetails() error {
+func PrintConfigDetails(streams *utils.IOStreams) error {
 	cfg, err := config.ReadConfig()
 	if err != nil {
 		return err
@@ -50,13 +54,13 @@ func PrintConfigDetails() error {
 		return err
 	}
 
-	fmt.Println("Config files:")
-	fmt.Println("-", f)
+	fmt.Fprintln(streams.Out, "Config files:")
+	fmt.Fprintln(streams.Out, "-", f)
 
-	fmt.Println()
+	fmt.Fprintln(streams.Out)
 
 	cliExists := false
-	cli := jenkins.NewJenkinsCli(&context)
+	cli := jenkins.NewJenkinsCli(&context, streams)
 	cliPath, err := cli.GetCliPath()
 	if err != nil {
 		return err
@@ -66,43 +70,56 @@ func PrintConfigDetails() error {
 		cliExists = true
 	}
 
-	fmt.Println("Current context:", context.Name)
-	fmt.Println("CLI path:", cliPath)
-	fmt.Println("CLI exists:", cliExists)
+	fmt.Fprintln(streams.Out, "Current context:", context.Name)
+	fmt.Fprintln(streams.Out, "CLI path:", cliPath)
+	fmt.Fprintln(streams.Out, "CLI exists:", cliExists)
 
-	y, err := os.ReadFile(f)
+	// The API token is never shown
+	redacted := cfg
+	redacted.Contexts = make([]config.Context, len(cfg.Contexts))
+	for i, c := range cfg.Contexts {
+		c.ApiToken = ""
+		redacted.Contexts[i] = c
+	}
+
+	y, err := yaml.Marshal(redacted)
 	if err != nil {
 		return err
 	}
 
-	fmt.Println()
+	fmt.Fprintln(streams.Out)
 
-	color.Set(color.FgWhite, color.Bold)
-	fmt.Printf("Contents of %s:\n", f)
-	color.Unset()
+	color.New(color.FgWhite, color.Bold).Fprintf(streams.Out, "Contents of %s (API tokens omitted):\n", f)
 
-	fmt.Println()
+	fmt.Fprintln(streams.Out)
 
-	return PrintYaml(string(y))
+	return PrintYaml(streams.Out, string(y))
 }
 
-func PrintYaml(s string) error {
+func PrintYaml(out io.Writer, s string) error {
 	// Indent string block
 	s = fmt.Sprintf("    %s\n", strings.Replace(s, "\n", "\n    ", -1))
 
-	return quick.Highlight(os.Stdout, s, "yaml", "terminal256", "github")
+	return quick.Highlight(out, s, "yaml", "terminal256", "github")
 }
 
 // debugCmd represents the debug command
 var debugCmd = &cobra.Command{
 	Use:    "debug",
 	Hidden: true,
-	Run: func(cmd *cobra.Command, args []string) {
-		err := PrintConfigDetails()
+	RunE: func(cmd *cobra.Command, args []string) error {
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
 		}
+
+		if err := p.RequireTable(cmd); err != nil {
+			return err
+		}
+
+		return PrintConfigDetails(&streams)
 	},
 }
 
@@ -0,0 +1,110 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var deleteForce bool
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete <context>",
+	Short: "Delete a Jenkins context",
+	Long: `Delete a Jenkins context along with its API token and cached data.
+
+The current context is only deleted with --force, which leaves no context
+selected.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return deleteContext(cmd, args[0])
+	},
+}
+
+func init() {
+	deleteCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "Delete the context even if it is the current context")
+
+	ContextCmd.AddCommand(deleteCmd)
+}
+
+func deleteContext(cmd *cobra.Command, name string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	context, err := cfg.GetContext(name)
+	if err != nil {
+		return err
+	}
+
+	current := cfg.CurrentContext == name
+	if current && !deleteForce {
+		return errs.New(errs.KindValidation, "Context '%s' is the current context. Use --force to delete it anyway.", name)
+	}
+
+	if err := cfg.DeleteContext(name); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if store, err := context.GetSecretStore(); err == nil {
+		if err := store.Delete(&context); err != nil {
+			return err
+		}
+	}
+
+	if err := context.RemoveContextDir(); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Deleted string `json:"deleted"`
+		}{name})
+	}
+
+	fmt.Fprintln(streams.Out, "Deleted context", name)
+	if current {
+		fmt.Fprintln(streams.Out, "No context is selected now. Use 'jenkinsw context use' to select one.")
+	}
+
+	return nil
+}
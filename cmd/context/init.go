@@ -22,13 +22,11 @@ THE SOFTWARE.
 package context
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
 	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 // initCmd represents the init command
@@ -38,11 +36,10 @@ var initCmd = &cobra.Command{
 	Long: `A longer description that spans multiple lines and likely contains examples
 to quickly create a Cobra application.`,
 	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.ReadConfig()
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
 		}
 
 		var context config.Context
@@ -54,16 +51,17 @@ to quickly create a Cobra application.`,
 		}
 
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
 		}
 
-		cli := jenkins.NewJenkinsCli(&context)
+		streams := utils.CommandStreams(cmd)
+		cli := jenkins.NewJenkinsCli(&context, &streams)
 
-		if err = cli.DownloadCliJar(); err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+		if err = cli.DownloadCliJar(cmd.Context()); err != nil {
+			return err
 		}
+
+		return nil
 	},
 }
 
@@ -22,49 +22,74 @@ THE SOFTWARE.
 package context
 
 import (
-	"fmt"
-	"os"
-
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
-func ListContexts() error {
+// contextEntry is a context as printed by the list command
+type contextEntry struct {
+	Name     string `json:"name"`
+	Host     string `json:"host"`
+	Username string `json:"username"`
+	Current  bool   `json:"current"`
+}
+
+type contextList []contextEntry
+
+func (l contextList) Header() []string {
+	return []string{"CURRENT", "NAME", "HOST", "USERNAME"}
+}
+
+func (l contextList) Rows() [][]string {
+	rows := make([][]string, len(l))
+	for i, c := range l {
+		current := ""
+		if c.Current {
+			current = "*"
+		}
+		rows[i] = []string{current, c.Name, c.Host, c.Username}
+	}
+
+	return rows
+}
+
+func ListContexts(cmd *cobra.Command) error {
 	cfg, err := config.ReadConfig()
 	if err != nil {
-		fmt.Println("Error:", err)
-		os.Exit(1)
+		return err
 	}
 
-	current, err := cfg.GetCurrentContext()
+	streams := utils.CommandStreams(cmd)
+
+	p, err := printer.FromCommand(cmd, &streams)
 	if err != nil {
-		fmt.Println("Error:", err)
-		os.Exit(1)
+		return err
 	}
 
+	list := contextList{}
 	for _, ctx := range cfg.Contexts {
-		if ctx.Name == current.Name {
-			fmt.Print("* ")
-			color.Green(ctx.Name)
-		} else {
-			fmt.Printf("  %s\n", ctx.Name)
-		}
+		list = append(list, contextEntry{
+			Name:     ctx.Name,
+			Host:     ctx.Host,
+			Username: ctx.Username,
+			Current:  ctx.Name == cfg.CurrentContext,
+		})
 	}
 
-	return nil
+	return p.Print(list)
 }
 
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List configured Jenkins contexts",
-	Long: `A longer description that spans multiple lines and likely contains examples
-to quickly create a Cobra application.`,
-	Args: cobra.NoArgs,
-	Run: func(cmd *cobra.Command, args []string) {
-		ListContexts()
+	Long:  `List the configured Jenkins contexts, marking the current context.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return ListContexts(cmd)
 	},
 }
 
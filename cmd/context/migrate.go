@@ -0,0 +1,175 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package context
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	migrateStore  string
+	migrateHelper string
+)
+
+// migrateSecretsCmd represents the migrate-secrets command
+var migrateSecretsCmd = &cobra.Command{
+	Use:   "migrate-secrets [context...]",
+	Short: "Move API tokens to another secret store",
+	Long: `Move the API tokens of the given contexts, or of all contexts, to another
+secret store, and remove the plaintext auth files left by earlier versions.
+
+  jenkinsw context migrate-secrets  # encrypts all tokens with a passphrase
+  jenkinsw context migrate-secrets ci --to helper --credential-helper docker-credential-pass`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return migrateSecrets(cmd, args)
+	},
+}
+
+func init() {
+	migrateSecretsCmd.Flags().StringVar(&migrateStore, "to", config.SecretStoreFile, "Secret store to move the tokens to: "+strings.Join(config.SecretStores, ", "))
+	migrateSecretsCmd.Flags().StringVar(&migrateHelper, "credential-helper", "", "Credential helper command of the helper secret store")
+
+	ContextCmd.AddCommand(migrateSecretsCmd)
+}
+
+// migration is the result of moving the API token of a context
+type migration struct {
+	Context     string `json:"context"`
+	SecretStore string `json:"secretStore"`
+	Moved       bool   `json:"moved"`
+}
+
+func migrateSecrets(cmd *cobra.Command, names []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		for _, ctx := range cfg.Contexts {
+			names = append(names, ctx.Name)
+		}
+	}
+
+	var migrated []config.Context
+	results := []migration{}
+	for _, name := range names {
+		context, err := cfg.GetContext(name)
+		if err != nil {
+			return err
+		}
+
+		updated := context
+		updated.SecretStore = migrateStore
+		updated.CredentialHelper = migrateHelper
+
+		if _, err := updated.GetSecretStore(); err != nil {
+			return errs.Wrap(errs.KindValidation, err)
+		}
+
+		if sameSecretStore(context, updated) {
+			results = append(results, migration{name, migrateStore, false})
+			if !pr.IsStructured() {
+				fmt.Fprintf(streams.Out, "Context %s already uses the %s secret store\n", name, migrateStore)
+			}
+		} else {
+			token, err := context.Token()
+			if err != nil {
+				return err
+			}
+
+			if err := updated.SetToken(token); err != nil {
+				return err
+			}
+
+			if err := cfg.SetContext(updated); err != nil {
+				return err
+			}
+
+			migrated = append(migrated, context)
+		}
+	}
+
+	// Old secrets are only removed once the config refers to the new ones
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	for _, context := range migrated {
+		if store, err := context.GetSecretStore(); err == nil {
+			if err := store.Delete(&context); err != nil {
+				return err
+			}
+		}
+
+		results = append(results, migration{context.Name, migrateStore, true})
+		if !pr.IsStructured() {
+			fmt.Fprintf(streams.Out, "Moved the API token of context %s to the %s secret store\n", context.Name, migrateStore)
+		}
+	}
+
+	for _, name := range names {
+		context, _ := cfg.GetContext(name)
+		if err := context.RemoveAuthFile(); err != nil {
+			return err
+		}
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(results)
+	}
+
+	return nil
+}
+
+// sameSecretStore reports whether both contexts keep their token in the
+// same place, in which case there is nothing to move
+func sameSecretStore(a config.Context, b config.Context) bool {
+	if a.SharesSecret(b) {
+		return true
+	}
+
+	storeA, storeB := a.SecretStore, b.SecretStore
+	if storeA == "" {
+		storeA = config.SecretStorePlain
+	}
+	if storeB == "" {
+		storeB = config.SecretStorePlain
+	}
+
+	return storeA == storeB && storeA != config.SecretStoreHelper
+}
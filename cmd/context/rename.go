@@ -0,0 +1,106 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package context
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// renameCmd represents the rename command
+var renameCmd = &cobra.Command{
+	Use:   "rename <context> <new-name>",
+	Short: "Rename a Jenkins context",
+	Long:  `Rename a Jenkins context, moving its API token and cached data along with it.`,
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return rename(cmd, args[0], args[1])
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(renameCmd)
+}
+
+func rename(cmd *cobra.Command, name string, newName string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	context, err := cfg.GetContext(name)
+	if err != nil {
+		return err
+	}
+
+	if err := cfg.RenameContext(name, newName); err != nil {
+		return err
+	}
+
+	renamed := context
+	renamed.Name = newName
+
+	if err := context.CopySecret(&renamed); err != nil {
+		return err
+	}
+
+	if err := context.MoveContextDir(renamed); err != nil {
+		renamed.DeleteCopiedSecret(context)
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		// Keep the directory and token with the context that is still in
+		// the config
+		renamed.MoveContextDir(context)
+		renamed.DeleteCopiedSecret(context)
+		return err
+	}
+
+	// The old token is only erased once the config refers to the new one
+	if err := context.DeleteCopiedSecret(renamed); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Context     string `json:"context"`
+			RenamedFrom string `json:"renamedFrom"`
+		}{newName, name})
+	}
+
+	fmt.Fprintf(streams.Out, "Renamed context %s to %s\n", name, newName)
+
+	return nil
+}
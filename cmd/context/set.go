@@ -0,0 +1,134 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package context
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// setCmd represents the set command
+var setCmd = &cobra.Command{
+	Use:   "set <context> <field>=<value>...",
+	Short: "Change fields of a Jenkins context",
+	Long: fmt.Sprintf(`Change fields of a Jenkins context. An empty value unsets an optional field.
+
+Fields: %s
+
+  jenkinsw context set ci host=https://jenkins.example.com username=me
+  jenkinsw context set ci transport=ssh sshEndpoint=`, strings.Join(config.Fields, ", ")),
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return set(cmd, args[0], args[1:])
+	},
+}
+
+func init() {
+	ContextCmd.AddCommand(setCmd)
+}
+
+func set(cmd *cobra.Command, name string, fields []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return err
+	}
+
+	context, err := cfg.GetContext(name)
+	if err != nil {
+		return err
+	}
+
+	updated := context
+	for _, f := range fields {
+		field, value, ok := strings.Cut(f, "=")
+		if !ok {
+			return errs.New(errs.KindValidation, "Invalid field '%s', expected field=value", f)
+		}
+
+		if err := updated.Set(field, value); err != nil {
+			return err
+		}
+	}
+
+	if err := cfg.SetContext(updated); err != nil {
+		return err
+	}
+
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if updated.Host != context.Host {
+		if err := clearServerCache(updated); err != nil {
+			return err
+		}
+	}
+
+	if pr.IsStructured() {
+		// The API token is never shown
+		updated.ApiToken = ""
+		return pr.Print(updated)
+	}
+
+	fmt.Fprintln(streams.Out, "Updated context", name)
+
+	return nil
+}
+
+// clearServerCache removes the data cached from the previous Jenkins server
+// of the context: discovered jobs and SSH host keys.
+func clearServerCache(ctx config.Context) error {
+	dir, err := ctx.GetContextDir()
+	if err != nil {
+		return err
+	}
+
+	knownHosts, err := ctx.GetKnownHostsFile()
+	if err != nil {
+		return err
+	}
+
+	for _, f := range []string{filepath.Join(dir, project.JobCacheFile), knownHosts} {
+		if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -22,12 +22,11 @@ THE SOFTWARE.
 package context
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 // showCmd represents the show command
@@ -36,11 +35,10 @@ var showCmd = &cobra.Command{
 	Short: "Show details of a specific Jenkins context (default: current context)",
 	Long:  `Show details of the specified Jenkins context. Defaults to showing the current context if none is specified.`,
 	Args:  cobra.RangeArgs(0, 1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.ReadConfig()
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
 		}
 
 		var context config.Context
@@ -52,14 +50,42 @@ var showCmd = &cobra.Command{
 		}
 
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
+		}
+
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		// The API token is never shown
+		context.ApiToken = ""
+
+		store := context.SecretStore
+		if store == "" {
+			store = config.SecretStorePlain
+		}
+		if context.CredentialHelper != "" {
+			store += " (" + context.CredentialHelper + ")"
 		}
 
-		fmt.Println("Context:", context.Name)
-		fmt.Println("Jenkins URL:", context.Host)
-		fmt.Println("Username:", context.Username)
-		fmt.Println("API Token:", context.ApiToken)
+		if p.IsStructured() {
+			if err := p.Print(context); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		p.PrintTable(nil, [][]string{
+			{"Context:", context.Name},
+			{"Jenkins URL:", context.Host},
+			{"Username:", context.Username},
+			{"Secret store:", store},
+		})
+
+		return nil
 	},
 }
 
@@ -22,16 +22,18 @@ THE SOFTWARE.
 package context
 
 import (
+	"bytes"
 	"fmt"
-	"os"
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 
 	log "github.com/sirupsen/logrus"
 
-	config "github.com/thecodesmith/jenkinsw/pkg/config"
 	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 // testCmd represents the test command
@@ -39,40 +41,56 @@ var testCmd = &cobra.Command{
 	Use:   "test",
 	Short: "Test connection to Jenkins",
 	Long:  `Test the connection to Jenkins using the URL and credentials from the current context.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := test(); err != nil {
-			color.Red("Error: connection failed")
-			os.Exit(1)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := test(cmd); err != nil {
+			return fmt.Errorf("connection failed: %w", err)
 		}
+
+		return nil
 	},
 }
 
-func test() error {
-	log.Debug("Testing connection")
-	cfg, err := config.ReadConfig()
+func test(cmd *cobra.Command) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
 	if err != nil {
 		return err
 	}
 
-	ctx, err := cfg.GetCurrentContext()
+	log.Debug("Testing connection")
+	p, err := project.Load()
 	if err != nil {
 		return err
 	}
 
-	cli := jenkins.NewJenkinsCli(&ctx)
+	ctx, err := p.CurrentContext()
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Connecting to %s as user %s\n", ctx.Host, ctx.Username)
-	out, err := cli.RunCommand("who-am-i")
-	if err == nil {
-		fmt.Println("Success!")
-	} else {
-		color.Red(string(out))
+	var out bytes.Buffer
+	cli := jenkins.NewJenkinsCli(&ctx, &utils.IOStreams{Out: &out, ErrOut: &out})
+
+	if !pr.IsStructured() {
+		fmt.Fprintf(streams.Out, "Connecting to %s as user %s\n", ctx.Host, ctx.Username)
 	}
 
-	return err
+	if err := cli.RunCommand(cmd.Context(), []string{"who-am-i"}, nil); err != nil {
+		fmt.Fprint(streams.ErrOut, color.RedString(out.String()))
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Host     string `json:"host"`
+			Username string `json:"username"`
+		}{ctx.Host, ctx.Username})
+	}
+
+	fmt.Fprintln(streams.Out, "Success!")
+
+	return nil
 }
 
 func init() {
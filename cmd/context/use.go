@@ -22,9 +22,6 @@ THE SOFTWARE.
 package context
 
 import (
-	"fmt"
-	"os"
-
 	"github.com/spf13/cobra"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
@@ -37,20 +34,15 @@ var useCmd = &cobra.Command{
 	Long: `A longer description that spans multiple lines and likely contains examples
 to quickly create a Cobra application.`,
 	Args: cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		name := args[0]
 
 		cfg, err := config.ReadConfig()
 		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
+			return err
 		}
 
-		err = cfg.UseContext(name)
-		if err != nil {
-			fmt.Println("Error:", err)
-			os.Exit(1)
-		}
+		return cfg.UseContext(name)
 	},
 }
 
@@ -0,0 +1,79 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package input
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// abortCmd represents the abort command
+var abortCmd = &cobra.Command{
+	Use:   "abort [build]",
+	Short: "Abort a pending input step",
+	Long:  `Abort a pending input step, which aborts the build.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return abort(cmd, args)
+	},
+}
+
+func init() {
+	abortCmd.Flags().StringVar(&inputId, "id", "", "ID of the input step (default: the only pending input)")
+	InputCmd.AddCommand(abortCmd)
+}
+
+func abort(cmd *cobra.Command, args []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	pb, err := getPendingBuild(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	in, err := pb.selectInput(inputId)
+	if err != nil {
+		return err
+	}
+
+	if err := pb.client.AbortInput(cmd.Context(), pb.job, pb.build, in); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(inputResult{Job: pb.job, Build: jenkins.BuildRef(pb.build), Input: in.Id, Action: "aborted"})
+	}
+
+	fmt.Fprintf(streams.Out, "Aborted input %s of %s #%s\n", in.Id, pb.job, jenkins.BuildRef(pb.build))
+
+	return nil
+}
@@ -0,0 +1,109 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package input
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/cmd/build"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/prompt"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var params []string
+
+// approveCmd represents the approve command
+var approveCmd = &cobra.Command{
+	Use:   "approve [build]",
+	Short: "Approve a pending input step",
+	Long: `Approve a pending input step, letting the build proceed.
+
+Input parameters are taken from -p values and prompted for when running in
+a terminal. Otherwise parameters without a value use their defaults.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return approve(cmd, args)
+	},
+}
+
+func init() {
+	approveCmd.Flags().StringVar(&inputId, "id", "", "ID of the input step (default: the only pending input)")
+	approveCmd.Flags().StringArrayVarP(&params, "param", "p", nil, "Input parameter as key=value (repeatable)")
+	InputCmd.AddCommand(approveCmd)
+}
+
+func approve(cmd *cobra.Command, args []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	pb, err := getPendingBuild(cmd, args)
+	if err != nil {
+		return err
+	}
+
+	in, err := pb.selectInput(inputId)
+	if err != nil {
+		return err
+	}
+
+	values := map[string]string{}
+	if err := build.ParseParams(params, values); err != nil {
+		return err
+	}
+
+	defs := make([]jenkins.ParameterDefinition, len(in.Inputs))
+	for i, p := range in.Inputs {
+		defs[i] = p.ParameterDefinition()
+	}
+
+	if len(defs) > 0 && prompt.New(&streams).IsInteractive() {
+		fmt.Fprintln(streams.ErrOut, color.CyanString(in.Message))
+		if values, err = build.PromptParameters(&streams, defs, values); err != nil {
+			return err
+		}
+	}
+
+	if err := jenkins.ValidateParameters(defs, values); err != nil {
+		return err
+	}
+
+	if err := pb.client.ProceedInput(cmd.Context(), pb.job, pb.build, in, values); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(inputResult{Job: pb.job, Build: jenkins.BuildRef(pb.build), Input: in.Id, Action: "approved"})
+	}
+
+	fmt.Fprintf(streams.Out, "Approved input %s of %s #%s\n", in.Id, pb.job, jenkins.BuildRef(pb.build))
+
+	return nil
+}
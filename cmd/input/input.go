@@ -0,0 +1,140 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package input
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job     string
+	branch  string
+	inputId string
+)
+
+// inputResult is the outcome of approving or aborting an input step
+type inputResult struct {
+	Job    string `json:"job"`
+	Build  string `json:"build"`
+	Input  string `json:"input"`
+	Action string `json:"action"`
+}
+
+var InputCmd = &cobra.Command{
+	Use:   "input",
+	Short: "List, approve and abort pending pipeline input steps",
+	Long: `List, approve and abort the input steps a pipeline build is waiting on.
+
+The build defaults to the last build of the current branch's job.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+func init() {
+	InputCmd.PersistentFlags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	InputCmd.PersistentFlags().StringVarP(&branch, "branch", "b", "", "Branch of the build (default: current git branch)")
+}
+
+// pendingBuild is a build with the input steps it is waiting on
+type pendingBuild struct {
+	client *jenkins.Client
+	job    string
+	build  string
+	inputs []jenkins.PendingInput
+}
+
+// getPendingBuild returns the pending inputs of the build given in args
+func getPendingBuild(cmd *cobra.Command, args []string) (pendingBuild, error) {
+	pb := pendingBuild{}
+	if len(args) == 1 {
+		pb.build = args[0]
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return pb, err
+	}
+
+	streams := utils.CommandStreams(cmd)
+
+	if pb.job, err = p.BranchJob(cmd.Context(), &streams, job, branch); err != nil {
+		return pb, err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return pb, err
+	}
+
+	if pb.client, err = jenkins.NewClient(cmd.Context(), &ctx, &streams); err != nil {
+		return pb, err
+	}
+
+	pb.inputs, err = pb.client.GetPendingInputs(cmd.Context(), pb.job, pb.build)
+
+	return pb, err
+}
+
+// selectInput returns the input with the given ID, or the only pending
+// input if no ID is given
+func (pb pendingBuild) selectInput(id string) (jenkins.PendingInput, error) {
+	name := fmt.Sprintf("%s #%s", pb.job, jenkins.BuildRef(pb.build))
+
+	if len(pb.inputs) == 0 {
+		return jenkins.PendingInput{}, errs.New(errs.KindNotFound, "%s is not waiting for input", name)
+	}
+
+	if id == "" {
+		if len(pb.inputs) == 1 {
+			return pb.inputs[0], nil
+		}
+
+		ids := make([]string, len(pb.inputs))
+		for i, in := range pb.inputs {
+			ids[i] = in.Id
+		}
+
+		return jenkins.PendingInput{}, errs.New(errs.KindValidation, "%s is waiting for several inputs, please select one with --id: %s", name, strings.Join(ids, ", "))
+	}
+
+	for _, in := range pb.inputs {
+		if strings.EqualFold(in.Id, id) {
+			return in, nil
+		}
+	}
+
+	return jenkins.PendingInput{}, errs.New(errs.KindNotFound, "%s has no pending input '%s'", name, id)
+}
@@ -0,0 +1,99 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package input
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list [build]",
+	Short: "List the input steps a build is waiting on",
+	Long:  `List the input steps a build is waiting on, with their messages, allowed submitters and parameters.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		pb, err := getPendingBuild(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		if p.IsStructured() {
+			if err := p.Print(pb.inputs); err != nil {
+				return err
+			}
+			return nil
+		}
+
+		if len(pb.inputs) == 0 {
+			fmt.Fprintf(streams.Out, "%s #%s is not waiting for input\n", pb.job, jenkins.BuildRef(pb.build))
+			return nil
+		}
+
+		for _, in := range pb.inputs {
+			printInput(streams.Out, in)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	InputCmd.AddCommand(listCmd)
+}
+
+func printInput(out io.Writer, in jenkins.PendingInput) {
+	fmt.Fprintln(out, color.CyanString(in.Message))
+	fmt.Fprintln(out, "  ID:", in.Id)
+	fmt.Fprintln(out, "  Proceed:", in.ProceedText)
+
+	if in.Submitter != "" {
+		fmt.Fprintln(out, "  Submitter:", in.Submitter)
+	}
+
+	if len(in.Inputs) > 0 {
+		fmt.Fprintln(out, "  Parameters:")
+		for _, p := range in.Inputs {
+			d := p.ParameterDefinition()
+			fmt.Fprintf(out, "    %s (%s) default: %q", d.Name, d.Kind(), d.Default())
+			if d.Description != "" {
+				fmt.Fprintf(out, " - %s", d.Description)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package job
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var refresh bool
+
+// whichCmd represents the which command
+var whichCmd = &cobra.Command{
+	Use:   "which",
+	Short: "Print the Jenkins job for the current repository",
+	Long: `Print the full name of the multibranch job for the current repository.
+
+The job is taken from the project file if present. Otherwise it is discovered
+by matching the repository's git remotes against the branch sources of the
+multibranch jobs on the server, and cached for subsequent lookups.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		job, err := which(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		if p.IsStructured() {
+			return p.Print(struct {
+				Job string `json:"job"`
+			}{job})
+		}
+
+		fmt.Fprintln(streams.Out, job)
+
+		return nil
+	},
+}
+
+func which(cmd *cobra.Command, streams *utils.IOStreams) (string, error) {
+	p, err := project.Load()
+	if err != nil {
+		return "", err
+	}
+
+	if !refresh || p.Job != "" {
+		return p.ResolveJob(cmd.Context(), streams, "")
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return "", err
+	}
+
+	return project.DiscoverJob(cmd.Context(), streams, ctx, true)
+}
+
+func init() {
+	whichCmd.Flags().BoolVar(&refresh, "refresh", false, "Ignore the cached job and discover it again")
+	JobCmd.AddCommand(whichCmd)
+}
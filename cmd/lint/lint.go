@@ -22,17 +22,20 @@ THE SOFTWARE.
 package lint
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
-	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	log "github.com/sirupsen/logrus"
 
-	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
 	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 var debugMode bool
@@ -43,39 +46,78 @@ var LintCmd = &cobra.Command{
 	Short: "Lint a Declarative Jenkinsfile",
 	Long: `Lint a Declarative Jenkinsfile.
 
-Automatically lint the Jenkinsfile in the current directory.
-Alternatively, provide the path to a Jenkinsfile elsewhere.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := lint(); err != nil {
-			color.Red("Error: validation failed", err)
-			os.Exit(1)
+Automatically lint the Jenkinsfiles declared in the project file, or the
+Jenkinsfile in the current directory. Alternatively, provide the path to a
+Jenkinsfile elsewhere.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		err := lint(cmd)
+
+		var cliErr *jenkins.CliError
+		if errors.As(err, &cliErr) && !cliErr.IsAuthFailure() {
+			return errs.New(errs.KindValidation, "validation failed")
 		}
+
+		return err
 	},
 }
 
 func init() {
 	LintCmd.Flags().BoolVarP(&debugMode, "debug", "d", false, "Enable debug output")
-	LintCmd.Flags().StringP("jenkinsfile", "j", "Jenkinsfile", "Path to Jenkinsfile")
+	LintCmd.Flags().StringP("jenkinsfile", "j", "", "Path to Jenkinsfile (default: from project file or Jenkinsfile)")
 	viper.BindPFlag("jenkinsfile", LintCmd.Flags().Lookup("jenkinsfile"))
 }
 
-func lint() error {
-	jenkinsfile := viper.Get("jenkinsfile")
-	log.Debug("Linting", jenkinsfile)
+func lint(cmd *cobra.Command) error {
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	jenkinsfiles := p.JenkinsfilePaths()
+	if f := viper.GetString("jenkinsfile"); f != "" {
+		jenkinsfiles = []string{f}
+	}
 
-	ctx, err := config.GetCurrentContext()
+	ctx, err := p.CurrentContext()
 	if err != nil {
 		return err
 	}
 
-	cli := jenkins.NewJenkinsCli(&ctx)
+	streams := utils.CommandStreams(cmd)
 
-	out, err := cli.RunCommand(fmt.Sprintf("declarative-linter < '%s'", jenkinsfile))
+	pr, err := printer.FromCommand(cmd, &streams)
 	if err != nil {
-		fmt.Println(string(out))
+		return err
+	}
+
+	// The linter reports in its own text format
+	if err := pr.RequireTable(cmd); err != nil {
+		return err
 	}
 
-	log.Debug("Result:", string(out))
+	cli := jenkins.NewJenkinsCli(&ctx, &streams)
+
+	for _, jenkinsfile := range jenkinsfiles {
+		log.Debug("Linting ", jenkinsfile)
+
+		if len(jenkinsfiles) > 1 {
+			fmt.Fprintln(streams.Out, "Linting", jenkinsfile)
+		}
+
+		if err := lintFile(cmd, cli, jenkinsfile); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func lintFile(cmd *cobra.Command, cli jenkins.JenkinsCli, jenkinsfile string) error {
+	f, err := os.Open(jenkinsfile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
 
-	return err
+	return cli.RunCommand(cmd.Context(), []string{"declarative-linter"}, f)
 }
@@ -0,0 +1,105 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package logs
+
+import (
+	"github.com/spf13/cobra"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job     string
+	branch  string
+	options jenkins.LogOptions
+)
+
+// LogsCmd represents the logs command
+var LogsCmd = &cobra.Command{
+	Use:   "logs [build]",
+	Short: "Display the logs for a multibranch pipeline job",
+	Long: `Display the console output of a build of the current branch's job.
+
+The branch job is resolved from the current git branch within the given
+multibranch job. Defaults to the last build if no build number is provided.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return logs(cmd, build)
+	},
+}
+
+func init() {
+	LogsCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	LogsCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to show logs for (default: current git branch)")
+	LogsCmd.Flags().BoolVarP(&options.Follow, "follow", "f", false, "Follow the console output until the build finishes")
+	LogsCmd.Flags().IntVar(&options.Tail, "tail", 0, "Number of lines to show from the end of the console output")
+	LogsCmd.Flags().StringVar(&options.SinceStage, "since-stage", "", "Show console output starting at the named stage")
+	LogsCmd.Flags().StringVar(&options.Stage, "stage", "", "Show only the logs of the steps of the named stage")
+}
+
+func logs(cmd *cobra.Command, build string) error {
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	streams := utils.CommandStreams(cmd)
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	// Console output has no structured form
+	if err := pr.RequireTable(cmd); err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	log.Debug("Showing logs for ", branchJob, " build ", jenkins.BuildRef(build))
+
+	return client.StreamConsole(cmd.Context(), branchJob, build, streams.Out, options)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package open
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job       string
+	branch    string
+	build     string
+	printOnly bool
+)
+
+var views = []string{"job", "build", "console", "changes", "tests", "blue"}
+
+// OpenCmd represents the open command
+var OpenCmd = &cobra.Command{
+	Use:   "open [job|build|console|changes|tests|blue]",
+	Short: "Open pipeline in browser",
+	Long: `Open the current branch's multibranch job in a browser.
+
+Views:
+  job      the branch job (default)
+  build    the build page
+  console  the console output of the build
+  changes  the changes included in the build
+  tests    the test report of the build
+  blue     the Blue Ocean view of the build or branch
+
+The browser is taken from $BROWSER, a colon-separated list of commands in
+which %s is replaced by the URL, falling back to the platform default.
+Use --print to print the URL instead, e.g. in headless sessions.`,
+	Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+	ValidArgs: views,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		view := "job"
+		if len(args) == 1 {
+			view = args[0]
+		}
+
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		url, err := getUrl(cmd, &streams, view)
+		if err != nil {
+			return err
+		}
+
+		if printOnly {
+			return printUrl(p, &streams, url)
+		}
+
+		if err := utils.OpenBrowser(url); err != nil {
+			printUrl(p, &streams, url)
+			return fmt.Errorf("unable to open browser: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	OpenCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	OpenCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to open (default: current git branch)")
+	OpenCmd.Flags().StringVarP(&build, "build", "n", "", "Build number to open (default: last build)")
+	OpenCmd.Flags().BoolVarP(&printOnly, "print", "p", false, "Print the URL instead of opening a browser")
+}
+
+func printUrl(p *printer.Printer, streams *utils.IOStreams, url string) error {
+	if p.IsStructured() {
+		return p.Print(struct {
+			Url string `json:"url"`
+		}{url})
+	}
+
+	_, err := fmt.Fprintln(streams.Out, url)
+
+	return err
+}
+
+func getUrl(cmd *cobra.Command, streams *utils.IOStreams, view string) (string, error) {
+	p, err := project.Load()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return "", err
+	}
+
+	multibranch, err := p.ResolveJob(cmd.Context(), streams, job)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := project.ResolveBranch(branch)
+	if err != nil {
+		return "", err
+	}
+
+	branchJob := jenkins.BranchJobName(multibranch, b)
+
+	switch view {
+	case "build":
+		return jenkins.BuildUrl(ctx.Host, branchJob, build), nil
+	case "console":
+		return jenkins.BuildUrl(ctx.Host, branchJob, build) + "console", nil
+	case "changes":
+		return jenkins.BuildUrl(ctx.Host, branchJob, build) + "changes", nil
+	case "tests":
+		return jenkins.BuildUrl(ctx.Host, branchJob, build) + "testReport/", nil
+	case "blue":
+		return jenkins.BlueOceanUrl(ctx.Host, multibranch, b, build), nil
+	default:
+		return jenkins.JobUrl(ctx.Host, branchJob), nil
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// deleteCmd represents the delete command
+var deleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a build parameter preset",
+	Long:  `Delete a build parameter preset saved for the current context.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		streams := utils.CommandStreams(cmd)
+
+		p, err := printer.FromCommand(cmd, &streams)
+		if err != nil {
+			return err
+		}
+
+		if err := deletePreset(args[0]); err != nil {
+			return err
+		}
+
+		if p.IsStructured() {
+			return p.Print(struct {
+				Deleted string `json:"deleted"`
+			}{args[0]})
+		}
+
+		fmt.Fprintln(streams.Out, "Deleted preset", args[0])
+
+		return nil
+	},
+}
+
+func init() {
+	PresetCmd.AddCommand(deleteCmd)
+}
+
+func deletePreset(name string) error {
+	_, ctx, presets, err := loadPresets()
+	if err != nil {
+		return err
+	}
+
+	if _, err := presets.Get(name); err != nil {
+		return err
+	}
+
+	delete(presets, name)
+
+	return presets.Save(ctx)
+}
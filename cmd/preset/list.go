@@ -0,0 +1,93 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List build parameter presets",
+	Long:  `List the build parameter presets saved for the current context.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list(cmd)
+	},
+}
+
+// presetEntry is a preset as printed by the list command
+type presetEntry struct {
+	Name string `json:"name"`
+	project.Preset
+}
+
+type presetList []presetEntry
+
+func (l presetList) Header() []string {
+	return []string{"NAME", "JOB", "PARAMETERS"}
+}
+
+func (l presetList) Rows() [][]string {
+	rows := make([][]string, len(l))
+	for i, e := range l {
+		job := e.Job
+		if job == "" {
+			job = "(current branch)"
+		}
+		rows[i] = []string{e.Name, job, fmt.Sprint(len(e.Parameters))}
+	}
+
+	return rows
+}
+
+func list(cmd *cobra.Command) error {
+	streams := utils.CommandStreams(cmd)
+
+	p, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	_, _, presets, err := loadPresets()
+	if err != nil {
+		return err
+	}
+
+	list := presetList{}
+	for _, name := range presets.Names() {
+		list = append(list, presetEntry{Name: name, Preset: presets[name]})
+	}
+
+	return p.Print(list)
+}
+
+func init() {
+	PresetCmd.AddCommand(listCmd)
+}
@@ -0,0 +1,65 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+)
+
+var PresetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Manage saved build parameter presets",
+	Long: `Manage named sets of build parameters, saved per context.
+
+A preset saved with a job always builds that job. Otherwise it builds the
+job of the current git branch.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 {
+			cmd.Help()
+			os.Exit(0)
+		}
+	},
+}
+
+// loadPresets returns the project, its current context and the presets
+// saved for that context
+func loadPresets() (project.Project, config.Context, project.Presets, error) {
+	p, err := project.Load()
+	if err != nil {
+		return p, config.Context{}, nil, err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return p, ctx, nil, err
+	}
+
+	presets, err := project.ReadPresets(ctx)
+
+	return p, ctx, presets, err
+}
@@ -0,0 +1,118 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/cmd/build"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	runParams []string
+	runBranch string
+	runWait   bool
+)
+
+// runCmd represents the run command
+var runCmd = &cobra.Command{
+	Use:   "run <name>",
+	Short: "Trigger a build with a parameter preset",
+	Long: `Trigger a build with the parameters of a preset, overridden by any -p values.
+
+With --wait, the command waits for the build and exits like 'jenkinsw build --wait'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		result, err := run(cmd, args[0])
+		if err != nil {
+			return err
+		}
+
+		if code := build.ExitCode(result); runWait && code != 0 {
+			return &errs.ExitError{Code: code}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	runCmd.Flags().StringArrayVarP(&runParams, "param", "p", nil, "Override a preset parameter as key=value (repeatable)")
+	runCmd.Flags().StringVarP(&runBranch, "branch", "b", "", "Branch to build if the preset has no job (default: current git branch)")
+	runCmd.Flags().BoolVarP(&runWait, "wait", "w", false, "Wait for the build to finish and exit with its result")
+	PresetCmd.AddCommand(runCmd)
+}
+
+func run(cmd *cobra.Command, name string) (string, error) {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return "", err
+	}
+
+	p, ctx, presets, err := loadPresets()
+	if err != nil {
+		return "", err
+	}
+
+	preset, err := presets.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	job := preset.Job
+	if job == "" {
+		if job, err = p.BranchJob(cmd.Context(), &streams, "", runBranch); err != nil {
+			return "", err
+		}
+	}
+
+	values := map[string]string{}
+	for k, v := range preset.Parameters {
+		values[k] = v
+	}
+
+	if err := build.ParseParams(runParams, values); err != nil {
+		return "", err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return "", err
+	}
+
+	defs, err := client.GetParameterDefinitions(cmd.Context(), job)
+	if err != nil {
+		return "", err
+	}
+
+	if err := jenkins.ValidateParameters(defs, values); err != nil {
+		return "", err
+	}
+
+	return build.Trigger(cmd, pr, client, job, defs, values, runWait)
+}
@@ -0,0 +1,123 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/cmd/build"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	saveParams    []string
+	saveFromBuild string
+	saveBranch    string
+)
+
+// saveCmd represents the save command
+var saveCmd = &cobra.Command{
+	Use:   "save <name> [job]",
+	Short: "Save a build parameter preset",
+	Long: `Save a named set of build parameters, replacing any preset of the same name.
+
+Parameters are given with -p, or captured from an existing build with
+--from-build, in which case -p values override the captured ones. Parameters
+are validated against the job's parameter definitions.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return save(cmd, args)
+	},
+}
+
+func init() {
+	saveCmd.Flags().StringArrayVarP(&saveParams, "param", "p", nil, "Build parameter as key=value (repeatable)")
+	saveCmd.Flags().StringVar(&saveFromBuild, "from-build", "", "Capture the parameters of an existing build")
+	saveCmd.Flags().StringVarP(&saveBranch, "branch", "b", "", "Branch whose job is used (default: current git branch)")
+	PresetCmd.AddCommand(saveCmd)
+}
+
+func save(cmd *cobra.Command, args []string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	p, ctx, presets, err := loadPresets()
+	if err != nil {
+		return err
+	}
+
+	preset := project.Preset{Parameters: map[string]string{}}
+
+	job := ""
+	if len(args) == 2 {
+		job = args[1]
+		preset.Job = job
+	} else if job, err = p.BranchJob(cmd.Context(), &streams, "", saveBranch); err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	if saveFromBuild != "" {
+		if preset.Parameters, err = client.GetBuildParameters(cmd.Context(), job, saveFromBuild); err != nil {
+			return err
+		}
+	}
+
+	if err := build.ParseParams(saveParams, preset.Parameters); err != nil {
+		return err
+	}
+
+	defs, err := client.GetParameterDefinitions(cmd.Context(), job)
+	if err != nil {
+		return err
+	}
+
+	if err := jenkins.ValidateParameters(defs, preset.Parameters); err != nil {
+		return err
+	}
+
+	presets[args[0]] = preset
+	if err := presets.Save(ctx); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(presetEntry{Name: args[0], Preset: preset})
+	}
+
+	fmt.Fprintf(streams.Out, "Saved preset %s with %d parameters\n", args[0], len(preset.Parameters))
+
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package preset
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// showCmd represents the show command
+var showCmd = &cobra.Command{
+	Use:   "show <name>",
+	Short: "Show a build parameter preset",
+	Long:  `Show the job and parameters of a build parameter preset.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		_, _, presets, err := loadPresets()
+		if err == nil {
+			err = show(cmd, presets, args[0])
+		}
+
+		if err != nil {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	PresetCmd.AddCommand(showCmd)
+}
+
+func show(cmd *cobra.Command, presets project.Presets, name string) error {
+	streams := utils.CommandStreams(cmd)
+
+	p, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	preset, err := presets.Get(name)
+	if err != nil {
+		return err
+	}
+
+	if p.IsStructured() {
+		return p.Print(presetEntry{Name: name, Preset: preset})
+	}
+
+	job := preset.Job
+	if job == "" {
+		job = "(current branch)"
+	}
+
+	fmt.Fprintln(streams.Out, "Preset:", name)
+	fmt.Fprintln(streams.Out, "Job:", job)
+	fmt.Fprintln(streams.Out, "Parameters:")
+
+	keys := make([]string, 0, len(preset.Parameters))
+	for k := range preset.Parameters {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fmt.Fprintf(streams.Out, "  %s=%s\n", k, preset.Parameters[k])
+	}
+
+	return nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package queue
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var cancelJob string
+
+// cancelCmd represents the cancel command
+var cancelCmd = &cobra.Command{
+	Use:   "cancel [id]",
+	Short: "Cancel queued builds",
+	Long:  `Cancel a queued build by its queue item ID, or all queued builds of a job with --job.`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return cancel(cmd, args)
+	},
+}
+
+func init() {
+	cancelCmd.Flags().StringVar(&cancelJob, "job", "", "Cancel all queued builds of the job with this full name")
+	QueueCmd.AddCommand(cancelCmd)
+}
+
+func cancel(cmd *cobra.Command, args []string) error {
+	if (len(args) == 1) == (cancelJob != "") {
+		return errs.New(errs.KindValidation, "Please provide either a queue item ID or --job")
+	}
+
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	cancelled := []int64{}
+
+	if len(args) == 1 {
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return errs.New(errs.KindValidation, "Invalid queue item ID '%s'", args[0])
+		}
+
+		if err := client.CancelQueueItem(cmd.Context(), id); err != nil {
+			return err
+		}
+
+		cancelled = append(cancelled, id)
+		return printCancelled(pr, &streams, cancelled)
+	}
+
+	items, err := client.ListQueue(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		if item.JobName() != cancelJob {
+			continue
+		}
+
+		if err := client.CancelQueueItem(cmd.Context(), item.Id); err != nil {
+			return err
+		}
+
+		cancelled = append(cancelled, item.Id)
+	}
+
+	if len(cancelled) == 0 {
+		return errs.New(errs.KindNotFound, "No queued builds of %s", cancelJob)
+	}
+
+	return printCancelled(pr, &streams, cancelled)
+}
+
+func printCancelled(pr *printer.Printer, streams *utils.IOStreams, ids []int64) error {
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Cancelled []int64 `json:"cancelled"`
+		}{ids})
+	}
+
+	for _, id := range ids {
+		fmt.Fprintln(streams.Out, "Cancelled queue item", id)
+	}
+
+	return nil
+}
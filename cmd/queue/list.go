@@ -0,0 +1,114 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package queue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// queueEntry is a queue item as printed by the list command
+type queueEntry struct {
+	Id      int64  `json:"id"`
+	Job     string `json:"job"`
+	Why     string `json:"why"`
+	Waiting string `json:"waiting"`
+}
+
+// listCmd represents the list command
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List queued builds",
+	Long:  `List the items in the build queue with their jobs, wait times and the reasons they are waiting.`,
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return list(cmd)
+	},
+}
+
+func init() {
+	QueueCmd.AddCommand(listCmd)
+}
+
+func list(cmd *cobra.Command) error {
+	streams := utils.CommandStreams(cmd)
+
+	p, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	client, err := newClient(cmd)
+	if err != nil {
+		return err
+	}
+
+	items, err := client.ListQueue(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	entries := queueList{}
+	for _, item := range items {
+		entries = append(entries, queueEntry{
+			Id:      item.Id,
+			Job:     jobName(item),
+			Why:     item.Why,
+			Waiting: item.Waiting(now).Round(time.Second).String(),
+		})
+	}
+
+	return p.Print(entries)
+}
+
+type queueList []queueEntry
+
+func (l queueList) Header() []string {
+	return []string{"ID", "JOB", "WAITING", "WHY"}
+}
+
+func (l queueList) Rows() [][]string {
+	rows := make([][]string, len(l))
+	for i, e := range l {
+		rows[i] = []string{fmt.Sprint(e.Id), e.Job, e.Waiting, strings.ReplaceAll(e.Why, "\n", " ")}
+	}
+
+	return rows
+}
+
+// jobName returns the full name of the queued job, falling back to the
+// task name for tasks that are not jobs
+func jobName(item jenkins.QueueItem) string {
+	if name := item.JobName(); name != "" {
+		return name
+	}
+
+	return item.Task.Name
+}
@@ -0,0 +1,120 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package replay
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	jenkinsfile string
+	job         string
+	branch      string
+	build       string
+)
+
+// ReplayCmd represents the replay command
+var ReplayCmd = &cobra.Command{
+	Use:   "replay",
+	Short: "Replay a multibranch pipeline job",
+	Long: `Replay a multibranch pipeline job using the local Jenkinsfile.
+
+The branch job is resolved from the current git branch within the given
+multibranch job, and the Jenkinsfile in the current directory is used as the
+main script for the replayed build.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := replay(cmd); err != nil {
+			return fmt.Errorf("replay failed: %w", err)
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	ReplayCmd.Flags().StringVarP(&jenkinsfile, "jenkinsfile", "j", "", "Path to Jenkinsfile (default: from project file or Jenkinsfile)")
+	ReplayCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	ReplayCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to replay (default: current git branch)")
+	ReplayCmd.Flags().StringVarP(&build, "build", "n", "", "Build number to replay (default: last build)")
+}
+
+func replay(cmd *cobra.Command) error {
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	if jenkinsfile == "" {
+		jenkinsfile = p.Jenkinsfile()
+	}
+
+	f, err := os.Open(jenkinsfile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	streams := utils.CommandStreams(cmd)
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	// The replayed build is reported in the text of the Jenkins CLI
+	if err := pr.RequireTable(cmd); err != nil {
+		return err
+	}
+
+	cli := jenkins.NewJenkinsCli(&ctx, &streams)
+
+	command := []string{"replay-pipeline", branchJob}
+	if build != "" {
+		command = append(command, "-n", build)
+	}
+
+	log.Debug("Replaying ", branchJob, " with ", jenkinsfile)
+	fmt.Fprintf(streams.Out, "Replaying %s with %s\n", branchJob, jenkinsfile)
+
+	return cli.RunCommand(cmd.Context(), command, f)
+}
@@ -0,0 +1,178 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package restart
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job    string
+	branch string
+	stage  string
+	follow bool
+)
+
+// RestartCmd represents the restart command
+var RestartCmd = &cobra.Command{
+	Use:   "restart [build]",
+	Short: "Restart a declarative pipeline from a stage",
+	Long: `Restart a completed declarative pipeline build from one of its stages.
+
+Without --stage, the stages the build can be restarted from are listed.
+Defaults to the last build of the current branch's job.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return restart(cmd, build)
+	},
+}
+
+func init() {
+	RestartCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	RestartCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch of the build (default: current git branch)")
+	RestartCmd.Flags().StringVarP(&stage, "stage", "s", "", "Stage to restart the build from")
+	RestartCmd.Flags().BoolVarP(&follow, "follow", "f", false, "Follow the console output of the restarted build")
+}
+
+func restart(cmd *cobra.Command, build string) error {
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	streams := utils.CommandStreams(cmd)
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	// The console output of the restarted build cannot be structured
+	if follow {
+		if err := pr.RequireTable(cmd); err != nil {
+			return err
+		}
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetBuildStatus(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	build = strconv.FormatInt(status.Number, 10)
+	if status.Building {
+		return errs.New(errs.KindValidation, "%s #%s is still running and cannot be restarted until it completes", branchJob, build)
+	}
+
+	stages, err := client.GetRestartableStages(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	if stage == "" {
+		if pr.IsStructured() {
+			return pr.Print(stages)
+		}
+
+		fmt.Fprintf(streams.Out, "Stages %s #%s can be restarted from:\n", branchJob, build)
+		for _, s := range stages {
+			fmt.Fprintln(streams.Out, " ", s)
+		}
+		return nil
+	}
+
+	name := ""
+	for _, s := range stages {
+		if strings.EqualFold(s, stage) {
+			name = s
+			break
+		}
+	}
+
+	if name == "" {
+		return errs.New(errs.KindValidation, "%s #%s cannot be restarted from stage '%s'. Restartable stages are: %s", branchJob, build, stage, strings.Join(stages, ", "))
+	}
+
+	// The restarted build gets at least the next build number, which is
+	// read before restarting so no earlier build can be mistaken for it
+	next, err := client.GetNextBuildNumber(cmd.Context(), branchJob)
+	if err != nil {
+		return err
+	}
+
+	if err := client.RestartFromStage(cmd.Context(), branchJob, build, name); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(struct {
+			Job   string `json:"job"`
+			Build int64  `json:"build"`
+			Stage string `json:"stage"`
+		}{branchJob, status.Number, name})
+	}
+
+	fmt.Fprintf(streams.Out, "Restarting %s #%s from stage '%s'\n", branchJob, build, name)
+
+	if !follow {
+		return nil
+	}
+
+	restarted, err := client.WaitForRestartedBuild(cmd.Context(), branchJob, status.Number, name, next)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(streams.Out, "Following %s #%d\n", branchJob, restarted)
+
+	return client.StreamConsole(cmd.Context(), branchJob, strconv.FormatInt(restarted, 10), streams.Out, jenkins.LogOptions{Follow: true})
+}
@@ -22,20 +22,49 @@ THE SOFTWARE.
 package cmd
 
 import (
+	gocontext "context"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/fatih/color"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	"github.com/thecodesmith/jenkinsw/cmd/artifacts"
+	"github.com/thecodesmith/jenkinsw/cmd/build"
+	"github.com/thecodesmith/jenkinsw/cmd/builds"
+	"github.com/thecodesmith/jenkinsw/cmd/cli"
 	"github.com/thecodesmith/jenkinsw/cmd/context"
+	"github.com/thecodesmith/jenkinsw/cmd/input"
+	"github.com/thecodesmith/jenkinsw/cmd/job"
 	"github.com/thecodesmith/jenkinsw/cmd/lint"
+	"github.com/thecodesmith/jenkinsw/cmd/logs"
+	"github.com/thecodesmith/jenkinsw/cmd/open"
+	"github.com/thecodesmith/jenkinsw/cmd/preset"
+	"github.com/thecodesmith/jenkinsw/cmd/queue"
+	"github.com/thecodesmith/jenkinsw/cmd/replay"
+	"github.com/thecodesmith/jenkinsw/cmd/restart"
+	"github.com/thecodesmith/jenkinsw/cmd/stages"
+	"github.com/thecodesmith/jenkinsw/cmd/stop"
+	"github.com/thecodesmith/jenkinsw/cmd/tests"
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/prompt"
 	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 var (
-	cfgFile string
-	ioStreams utils.IOStreams // read and write to this stream
+	cfgFile    string
+	timeout    time.Duration
+	jsonErrors bool
+	passphrase string
+	ioStreams  = utils.NewStdStreams() // read and write to this stream
+
+	envPassphrase = config.Passphrase
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -49,37 +78,150 @@ to provide context-aware pipeline operations.
 CLI commands default to utilizing the current repository's branch and
 Jenkinsfile, making it simple and fast to develop Jenkinsfiles for multibranch
 pipelines.`,
-	PreRun: func(cmd *cobra.Command, args []string) {
-		cmd.SetOut(ioStreams.Out)
-		cmd.SetErr(ioStreams.ErrOut)
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		color.NoColor = !ioStreams.ColorEnabled()
+
+		if timeout > 0 {
+			ctx, cancel := gocontext.WithTimeout(cmd.Context(), timeout)
+			cobra.OnFinalize(cancel)
+			cmd.SetContext(ctx)
+		}
 	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Commands are canceled on SIGINT and SIGTERM. Errors are reported on stderr
+// and mapped to the exit codes of the errs package.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(gocontext.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	validateUsage(rootCmd)
+
+	cmd, err := rootCmd.ExecuteContextC(ctx)
 	if err != nil {
-		os.Exit(1)
+		stop()
+
+		errs.Report(ioStreams.ErrOut, err, jsonErrors)
+
+		var usageErr *usageError
+		if errors.As(err, &usageErr) && !jsonErrors {
+			fmt.Fprintf(ioStreams.ErrOut, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		}
+
+		os.Exit(errs.ExitCode(err))
+	}
+}
+
+// usageError is an invalid flag or argument of a command
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+func newUsageError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &usageError{errs.Wrap(errs.KindValidation, err)}
+}
+
+// validateUsage reports invalid flags and arguments of the command and its
+// subcommands as usage errors.
+func validateUsage(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(func(c *cobra.Command, err error) error {
+		return newUsageError(err)
+	})
+
+	if args := cmd.Args; args != nil {
+		cmd.Args = func(c *cobra.Command, a []string) error {
+			return newUsageError(args(c, a))
+		}
+	}
+
+	for _, c := range cmd.Commands() {
+		validateUsage(c)
 	}
 }
 
 func init() {
 	cobra.OnInitialize(initConfig)
 
+	config.Passphrase = promptPassphrase
+
+	// Subcommands read and write the root streams
+	rootCmd.SetIn(ioStreams.In)
+	rootCmd.SetOut(ioStreams.Out)
+	rootCmd.SetErr(ioStreams.ErrOut)
+
+	rootCmd.AddCommand(artifacts.ArtifactsCmd)
+	rootCmd.AddCommand(build.BuildCmd)
+	rootCmd.AddCommand(builds.BuildsCmd)
+	rootCmd.AddCommand(cli.CliCmd)
 	rootCmd.AddCommand(context.ContextCmd)
+	rootCmd.AddCommand(input.InputCmd)
+	rootCmd.AddCommand(job.JobCmd)
 	rootCmd.AddCommand(lint.LintCmd)
+	rootCmd.AddCommand(logs.LogsCmd)
+	rootCmd.AddCommand(open.OpenCmd)
+	rootCmd.AddCommand(preset.PresetCmd)
+	rootCmd.AddCommand(queue.QueueCmd)
+	rootCmd.AddCommand(replay.ReplayCmd)
+	rootCmd.AddCommand(restart.RestartCmd)
+	rootCmd.AddCommand(stages.StagesCmd)
+	rootCmd.AddCommand(stop.StopCmd)
+	rootCmd.AddCommand(tests.TestsCmd)
 
-	// Here you will define your flags and configuration settings.
-	// Cobra supports persistent flags, which, if defined here,
-	// will be global for your application.
-
-	// rootCmd.PersistentFlags().StringP("host", "h", "", "Jenkins host URL")
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.jenkinsw.yaml)")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "maximum duration of the command, e.g. 30s or 5m (default is no timeout)")
+	rootCmd.PersistentFlags().StringP("output", "o", "", "output format: table, json, yaml or template=<Go template> (default is table)")
+	rootCmd.PersistentFlags().BoolVar(&jsonErrors, "json-errors", false, "report errors on stderr as JSON objects with error, kind and exitCode")
+}
+
+// promptPassphrase asks for the passphrase of encrypted secrets once per
+// command, unless it is set in the environment.
+func promptPassphrase(ctx config.Context, confirm bool) (string, error) {
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	p := prompt.New(&ioStreams)
+	if os.Getenv(config.PassphraseEnv) != "" || !p.IsInteractive() {
+		return envPassphrase(ctx, confirm)
+	}
+
+	answer, err := p.Password("Passphrase for secrets", "")
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return "", errs.New(errs.KindValidation, "A passphrase is required")
+	}
+
+	if confirm {
+		again, err := p.Password("Repeat passphrase", "")
+		if err != nil {
+			return "", err
+		}
+		if again != answer {
+			return "", errs.New(errs.KindValidation, "The passphrases do not match")
+		}
+	}
+
+	passphrase = answer
 
-	// Cobra also supports local flags, which will only run
-	// when this action is called directly.
-	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
+	return passphrase, nil
 }
 
 // initConfig reads in config file and ENV variables if set.
@@ -102,6 +244,6 @@ func initConfig() {
 
 	// If a config file is found, read it in.
 	if err := viper.ReadInConfig(); err == nil {
-		fmt.Fprintln(os.Stderr, "Using config file:", viper.ConfigFileUsed())
+		fmt.Fprintln(ioStreams.ErrOut, "Using config file:", viper.ConfigFileUsed())
 	}
 }
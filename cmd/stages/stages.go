@@ -0,0 +1,168 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package stages
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job    string
+	branch string
+)
+
+// StagesCmd represents the stages command
+var StagesCmd = &cobra.Command{
+	Use:   "stages [build]",
+	Short: "Show the stages of a pipeline build",
+	Long: `Show the stages of a build of the current branch's job with their status
+and duration. Defaults to the last build if no build number is provided.
+
+Nested stages, such as the stages of parallel branches, are shown under
+their parent stage.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return stages(cmd, build)
+	},
+}
+
+func init() {
+	StagesCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	StagesCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch to show stages for (default: current git branch)")
+}
+
+func stages(cmd *cobra.Command, build string) error {
+	streams := utils.CommandStreams(cmd)
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	run, err := client.DescribeRun(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	if err := client.ResolveStageParents(cmd.Context(), branchJob, build, &run); err != nil {
+		return err
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(run)
+	}
+
+	fmt.Fprintf(streams.Out, "%s %s %s (%s)\n", branchJob, run.Name, statusText(run.Status), duration(run.DurationMillis))
+
+	depths := depths(run.Stages)
+	for _, s := range run.Stages {
+		fmt.Fprintf(streams.Out, "%s%s %s %s\n", strings.Repeat("  ", depths[s.Id]+1), statusSymbol(s.Status), s.Name, color.HiBlackString(duration(s.DurationMillis)))
+	}
+
+	return nil
+}
+
+// depths returns how deeply each stage is nested by its id. Parents are
+// listed before their nested stages.
+func depths(stages []jenkins.Stage) map[string]int {
+	depths := map[string]int{}
+	for _, s := range stages {
+		if s.ParentId != "" {
+			depths[s.Id] = depths[s.ParentId] + 1
+		} else {
+			depths[s.Id] = 0
+		}
+	}
+
+	return depths
+}
+
+func duration(millis int64) string {
+	return (time.Duration(millis) * time.Millisecond).Round(time.Second).String()
+}
+
+func statusSymbol(status string) string {
+	switch status {
+	case jenkins.StageSuccess:
+		return color.GreenString("✔")
+	case jenkins.StageFailed:
+		return color.RedString("✘")
+	case jenkins.StageUnstable:
+		return color.YellowString("!")
+	case jenkins.StageInProgress:
+		return color.CyanString("▶")
+	case jenkins.StagePendingInput:
+		return color.CyanString("?")
+	case jenkins.StageAborted:
+		return color.HiBlackString("■")
+	default:
+		return color.HiBlackString("-")
+	}
+}
+
+func statusText(status string) string {
+	switch status {
+	case jenkins.StageSuccess:
+		return color.GreenString(status)
+	case jenkins.StageFailed:
+		return color.RedString(status)
+	case jenkins.StageUnstable:
+		return color.YellowString(status)
+	default:
+		return status
+	}
+}
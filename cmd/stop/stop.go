@@ -0,0 +1,148 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package stop
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job         string
+	branch      string
+	gracePeriod time.Duration
+)
+
+// StopCmd represents the stop command
+var StopCmd = &cobra.Command{
+	Use:   "stop [build]",
+	Short: "Abort a running build",
+	Long: `Abort a running build of the current branch's job, defaulting to the last build.
+
+If the build is still running after the grace period, the stop is escalated
+to the "term" and then the "kill" action, which forcibly stop pipelines.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return stop(cmd, build)
+	},
+}
+
+func init() {
+	StopCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	StopCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch of the build (default: current git branch)")
+	StopCmd.Flags().DurationVar(&gracePeriod, "grace-period", 10*time.Second, "How long to wait before escalating the stop")
+}
+
+// stopResult is the outcome of stopping a build
+type stopResult struct {
+	Job     string `json:"job"`
+	Build   int64  `json:"build"`
+	Result  string `json:"result"`
+	Stopped bool   `json:"stopped"`
+	Action  string `json:"action,omitempty"`
+}
+
+func stop(cmd *cobra.Command, build string) error {
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	streams := utils.CommandStreams(cmd)
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	pr, err := printer.FromCommand(cmd, &streams)
+	if err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	status, err := client.GetBuildStatus(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	if !status.Building {
+		if pr.IsStructured() {
+			return pr.Print(stopResult{Job: branchJob, Build: status.Number, Result: status.Result})
+		}
+
+		fmt.Fprintf(streams.Out, "%s #%d is not running\n", branchJob, status.Number)
+		return nil
+	}
+
+	for _, action := range jenkins.StopActions {
+		if !pr.IsStructured() {
+			fmt.Fprintf(streams.Out, "Sending %s to %s #%d\n", action, branchJob, status.Number)
+		}
+
+		if err := client.StopBuild(cmd.Context(), branchJob, fmt.Sprint(status.Number), action); err != nil {
+			return err
+		}
+
+		waitCtx, cancel := context.WithTimeout(cmd.Context(), gracePeriod)
+		final, err := client.WaitForBuild(waitCtx, branchJob, status.Number)
+		cancel()
+
+		if err == nil {
+			if pr.IsStructured() {
+				return pr.Print(stopResult{Job: branchJob, Build: final.Number, Result: final.Result, Stopped: true, Action: action})
+			}
+
+			fmt.Fprintf(streams.Out, "Stopped %s #%d: %s\n", branchJob, final.Number, final.Result)
+			return nil
+		}
+
+		if !errors.Is(err, context.DeadlineExceeded) || cmd.Context().Err() != nil {
+			return err
+		}
+	}
+
+	return fmt.Errorf("%s #%d is still running after %s", branchJob, status.Number, jenkins.KillAction)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright © 2022 Brian Stewart
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+package tests
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+var (
+	job     string
+	branch  string
+	compare string
+	brief   bool
+)
+
+// TestsCmd represents the tests command
+var TestsCmd = &cobra.Command{
+	Use:   "tests [build]",
+	Short: "Show the test results of a build",
+	Long: `Show the test results of a build of the current branch's job, defaulting
+to the last build.
+
+A summary of each suite is printed, followed by the failed tests with their
+error messages and stack traces. With --compare, the tests newly failing and
+newly fixed since the other build are listed instead. Besides the global
+output formats, --output junit exports the report as JUnit XML.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		build := ""
+		if len(args) == 1 {
+			build = args[0]
+		}
+
+		return tests(cmd, build)
+	},
+}
+
+func init() {
+	TestsCmd.Flags().StringVar(&job, "job", "", "Full name of the multibranch job (default: from project file)")
+	TestsCmd.Flags().StringVarP(&branch, "branch", "b", "", "Branch of the build (default: current git branch)")
+	TestsCmd.Flags().StringVar(&compare, "compare", "", "Build to compare the test results against")
+	TestsCmd.Flags().BoolVar(&brief, "brief", false, "Omit the stack traces of failed tests")
+}
+
+func tests(cmd *cobra.Command, build string) error {
+	streams := utils.CommandStreams(cmd)
+	output := cmd.Flag("output").Value.String()
+
+	var pr *printer.Printer
+	if output != "junit" {
+		var err error
+		if pr, err = printer.FromCommand(cmd, &streams); err != nil {
+			return err
+		}
+	}
+
+	if compare != "" && pr == nil {
+		return errs.New(errs.KindValidation, "The compare option does not support JUnit output")
+	}
+
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+
+	branchJob, err := p.BranchJob(cmd.Context(), &streams, job, branch)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+
+	report, err := client.GetTestReport(cmd.Context(), branchJob, build)
+	if err != nil {
+		return err
+	}
+
+	if pr == nil {
+		x, err := report.JUnit()
+		if err != nil {
+			return err
+		}
+		_, err = streams.Out.Write(x)
+		return err
+	}
+
+	if compare != "" {
+		base, err := client.GetTestReport(cmd.Context(), branchJob, compare)
+		if err != nil {
+			return err
+		}
+
+		comparison := jenkins.CompareTestReports(base, report)
+		if pr.IsStructured() {
+			return pr.Print(comparison)
+		}
+
+		printComparison(streams.Out, comparison)
+		return nil
+	}
+
+	if pr.IsStructured() {
+		return pr.Print(report)
+	}
+
+	printSummary(pr, report)
+	printFailures(streams.Out, report)
+
+	return nil
+}
+
+func printSummary(pr *printer.Printer, report jenkins.TestReport) {
+	var rows [][]string
+	for _, s := range report.Suites {
+		passed, failed, skipped := s.Counts()
+		rows = append(rows, []string{s.Name, fmt.Sprint(passed), fmt.Sprint(failed), fmt.Sprint(skipped)})
+	}
+	rows = append(rows, []string{"TOTAL", fmt.Sprint(report.PassCount), fmt.Sprint(report.FailCount), fmt.Sprint(report.SkipCount)})
+
+	pr.PrintTable([]string{"SUITE", "PASSED", "FAILED", "SKIPPED"}, rows)
+}
+
+func printFailures(out io.Writer, report jenkins.TestReport) {
+	failed := report.Failed()
+	if len(failed) == 0 {
+		return
+	}
+
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, color.RedString("Failed tests:"))
+
+	for _, t := range failed {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, color.RedString("✘ %s", t.FullName()))
+
+		if t.ErrorDetails != "" {
+			fmt.Fprintln(out, indent(t.ErrorDetails))
+		}
+
+		if !brief && t.ErrorStackTrace != "" {
+			fmt.Fprintln(out, color.HiBlackString(indent(t.ErrorStackTrace)))
+		}
+	}
+}
+
+func printComparison(out io.Writer, comparison jenkins.TestComparison) {
+	fmt.Fprintf(out, "Newly failing tests (%d):\n", len(comparison.NewlyFailing))
+	for _, name := range comparison.NewlyFailing {
+		fmt.Fprintln(out, color.RedString("  ✘ %s", name))
+	}
+
+	fmt.Fprintf(out, "Newly fixed tests (%d):\n", len(comparison.NewlyFixed))
+	for _, name := range comparison.NewlyFixed {
+		fmt.Fprintln(out, color.GreenString("  ✔ %s", name))
+	}
+}
+
+func indent(text string) string {
+	return "    " + strings.ReplaceAll(strings.TrimRight(text, "\n"), "\n", "\n    ")
+}
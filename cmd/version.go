@@ -23,50 +23,79 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/fatih/color"
 	// log "github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
-	config "github.com/thecodesmith/jenkinsw/pkg/config"
 	jenkins "github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/printer"
+	"github.com/thecodesmith/jenkinsw/pkg/project"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Display version info for the Jenkins server, CLI, and wrapper",
 	Long:  `Display the version info for the Jenkins server, Jenkins CLI, and Jenkins wrapper CLI.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		if err := printVersion(); err != nil {
-			color.Red("Error:", err)
-			os.Exit(1)
-		}
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return printVersion(cmd)
 	},
 }
 
-func printVersion() (err error) {
-	fmt.Printf("%s version: %s\n", rootCmd.Use, rootCmd.Version)
-	fmt.Println("")
+// versionInfo is the version info printed by the version command
+type versionInfo struct {
+	Wrapper       string `json:"wrapper"`
+	ProjectFile   string `json:"projectFile,omitempty"`
+	Host          string `json:"host"`
+	ServerVersion string `json:"serverVersion"`
+	CliVersion    string `json:"cliVersion,omitempty"`
+}
 
-	ctx, err := config.GetCurrentContext()
+func printVersion(cmd *cobra.Command) (err error) {
+	streams := utils.CommandStreams(cmd)
 
+	pr, err := printer.FromCommand(cmd, &streams)
 	if err != nil {
 		return err
 	}
 
-	fmt.Print("Jenkins server: ")
-	color.Blue(ctx.Host)
+	info := versionInfo{Wrapper: rootCmd.Version}
 
-	fmt.Print("  Jenkins server version: ")
-	client, err := jenkins.NewClient(&ctx, &ioStreams)
-	serverVersion := client.Version()
-	fmt.Println(serverVersion)
+	p, err := project.Load()
+	if err != nil {
+		return err
+	}
+	info.ProjectFile = p.File
 
+	ctx, err := p.CurrentContext()
+	if err != nil {
+		return err
+	}
+	info.Host = ctx.Host
+
+	client, err := jenkins.NewClient(cmd.Context(), &ctx, &streams)
+	if err != nil {
+		return err
+	}
+	info.ServerVersion = client.Version()
+
+	cli := jenkins.NewJenkinsCli(&ctx, &streams)
+	info.CliVersion, _ = cli.Version()
+
+	if pr.IsStructured() {
+		return pr.Print(info)
+	}
+
+	fmt.Fprintf(streams.Out, "%s version: %s\n", rootCmd.Use, info.Wrapper)
+	fmt.Fprintln(streams.Out, "")
+
+	if info.ProjectFile != "" {
+		fmt.Fprintln(streams.Out, "Project file:", info.ProjectFile)
+	}
 
-	fmt.Print("  Jenkins CLI jar version: ")
-	cli := jenkins.NewJenkinsCli(&ctx)
-	cliVersion, err := cli.Version()
-	fmt.Println(cliVersion)
+	fmt.Fprintln(streams.Out, "Jenkins server:", color.BlueString(info.Host))
+	fmt.Fprintln(streams.Out, "  Jenkins server version:", info.ServerVersion)
+	fmt.Fprintln(streams.Out, "  Jenkins CLI jar version:", info.CliVersion)
 
 	return nil
 }
@@ -23,10 +23,15 @@ package context
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/ghodss/yaml"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
 )
 
 type Config struct {
@@ -35,10 +40,72 @@ type Config struct {
 }
 
 type Context struct {
-	Name     string `json:"name"`
-	Host     string `json:"host"`
-	Username string `json:"username"`
-	ApiToken string `json:"apiToken"`
+	Name      string `json:"name"`
+	Host      string `json:"host"`
+	Username  string `json:"username"`
+	ApiToken  string `json:"apiToken,omitempty"`
+	Transport string `json:"transport,omitempty"`
+
+	// SecretStore holds the API token, which is kept in ApiToken by the
+	// default plain store, see SecretStores
+	SecretStore string `json:"secretStore,omitempty"`
+
+	// CredentialHelper is the command of the helper secret store
+	CredentialHelper string `json:"credentialHelper,omitempty"`
+
+	// SshEndpoint is the host:port of the Jenkins SSH server, discovered
+	// from the X-SSH-Endpoint header if empty
+	SshEndpoint string `json:"sshEndpoint,omitempty"`
+
+	// SshKeyFile is the private key used by the SSH transport, which falls
+	// back to the SSH agent if empty
+	SshKeyFile string `json:"sshKeyFile,omitempty"`
+}
+
+const (
+	TransportWebSocket = "websocket"
+	TransportJar       = "jar"
+	TransportSSH       = "ssh"
+)
+
+// GetTransport returns the transport used to run CLI commands, defaulting
+// to the native WebSocket transport.
+func (c Context) GetTransport() (string, error) {
+	switch c.Transport {
+	case "":
+		return TransportWebSocket, nil
+	case TransportWebSocket, TransportJar, TransportSSH:
+		return c.Transport, nil
+	default:
+		return "", errs.New(errs.KindConfig, "Unknown transport '%s' for context '%s'. Valid transports are: %s, %s, %s", c.Transport, c.Name, TransportWebSocket, TransportJar, TransportSSH)
+	}
+}
+
+var contextNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ValidateName checks that a context name can be used as a directory name
+func ValidateName(name string) error {
+	if !contextNamePattern.MatchString(name) {
+		return errs.New(errs.KindValidation, "Invalid context name '%s'. Names may contain letters, digits, '.', '_' and '-'.", name)
+	}
+
+	return nil
+}
+
+// NormalizeHost validates a Jenkins URL and returns it without trailing
+// slashes, defaulting to https if no scheme is given.
+func NormalizeHost(host string) (string, error) {
+	host = strings.TrimSpace(host)
+	if host != "" && !strings.Contains(host, "://") {
+		host = "https://" + host
+	}
+
+	u, err := url.Parse(host)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.RawQuery != "" || u.Fragment != "" {
+		return "", errs.New(errs.KindValidation, "Invalid Jenkins URL '%s'. Please provide a URL like https://jenkins.example.com.", host)
+	}
+
+	return strings.TrimRight(u.String(), "/"), nil
 }
 
 const ConfigDir = ".jenkinsw"
@@ -59,7 +126,7 @@ func ReadConfig() (Config, error) {
 	f := filepath.Join(homeDir, ConfigDir, ConfigFile)
 
 	if _, err := os.Stat(f); os.IsNotExist(err) {
-		fmt.Printf("Config file %s does not exist, creating it\n", f)
+		fmt.Fprintf(os.Stderr, "Config file %s does not exist, creating it\n", f)
 		f2, err := os.Create(f)
 		if err != nil {
 			return Config{}, err
@@ -81,7 +148,7 @@ func ReadConfig() (Config, error) {
 	var config Config
 	err = yaml.Unmarshal(y, &config)
 	if err != nil {
-		return Config{}, err
+		return Config{}, errs.New(errs.KindConfig, "Invalid config file %s: %s", f, err)
 	}
 
 	return config, nil
@@ -89,18 +156,20 @@ func ReadConfig() (Config, error) {
 
 func (c Config) Save() error {
 	y, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
 
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
-		fmt.Printf("Error: %s\n", err)
-		os.Exit(1)
+		return err
 	}
 
 	dir := filepath.Join(homeDir, ConfigDir)
 
 	err = os.MkdirAll(dir, 0700)
 	if err != nil {
-		return fmt.Errorf("Error: %s\n", err)
+		return err
 	}
 
 	configFile := filepath.Join(dir, ConfigFile)
@@ -118,13 +187,17 @@ func GetCurrentContext() (Context, error) {
 }
 
 func (c Config) GetCurrentContext() (Context, error) {
+	if c.CurrentContext == "" {
+		return Context{}, errs.New(errs.KindConfig, "No current context set. Use 'jenkinsw context use' to select one of the available contexts.")
+	}
+
 	for _, ctx := range c.Contexts {
 		if ctx.Name == c.CurrentContext {
 			return ctx, nil
 		}
 	}
 
-	return Context{}, fmt.Errorf("Context named '%s' not found", c.CurrentContext)
+	return Context{}, errs.New(errs.KindConfig, "Current context '%s' not found. Use 'jenkinsw context use' to select one of the available contexts.", c.CurrentContext)
 }
 
 func (c Config) GetContext(name string) (Context, error) {
@@ -134,13 +207,19 @@ func (c Config) GetContext(name string) (Context, error) {
 		}
 	}
 
-	return Context{}, fmt.Errorf("Context named '%s' not found", c.CurrentContext)
+	return Context{}, errs.New(errs.KindNotFound, "Context named '%s' not found", name)
 }
 
-func (c Config) AddContext(context Context) error {
+// AddContext adds a new context, making it the current context if there is
+// none yet.
+func (c *Config) AddContext(context Context) error {
+	if err := ValidateName(context.Name); err != nil {
+		return err
+	}
+
 	for _, ctx := range c.Contexts {
 		if ctx.Name == context.Name {
-			return fmt.Errorf("Context named '%s' already exists", context.Name)
+			return errs.New(errs.KindValidation, "Context named '%s' already exists", context.Name)
 		}
 	}
 
@@ -153,9 +232,60 @@ func (c Config) AddContext(context Context) error {
 	return nil
 }
 
+// DeleteContext removes a context, unsetting the current context if it is
+// the removed one.
+func (c *Config) DeleteContext(name string) error {
+	for i, ctx := range c.Contexts {
+		if ctx.Name == name {
+			c.Contexts = append(c.Contexts[:i], c.Contexts[i+1:]...)
+			if c.CurrentContext == name {
+				c.CurrentContext = ""
+			}
+			return nil
+		}
+	}
+
+	return errs.New(errs.KindNotFound, "Context named '%s' not found", name)
+}
+
+// RenameContext renames a context, keeping it the current context if it is
+func (c *Config) RenameContext(name string, newName string) error {
+	if err := ValidateName(newName); err != nil {
+		return err
+	}
+
+	if c.IsExistingContext(newName) {
+		return errs.New(errs.KindValidation, "Context named '%s' already exists", newName)
+	}
+
+	for i, ctx := range c.Contexts {
+		if ctx.Name == name {
+			c.Contexts[i].Name = newName
+			if c.CurrentContext == name {
+				c.CurrentContext = newName
+			}
+			return nil
+		}
+	}
+
+	return errs.New(errs.KindNotFound, "Context named '%s' not found", name)
+}
+
+// SetContext replaces the context with the same name
+func (c *Config) SetContext(context Context) error {
+	for i, ctx := range c.Contexts {
+		if ctx.Name == context.Name {
+			c.Contexts[i] = context
+			return nil
+		}
+	}
+
+	return errs.New(errs.KindNotFound, "Context named '%s' not found", context.Name)
+}
+
 func (c Config) UseContext(name string) error {
 	if !c.IsExistingContext(name) {
-		return fmt.Errorf("No context named '%s'. Use 'jenkinsw context list' to view available contexts.", name)
+		return errs.New(errs.KindNotFound, "No context named '%s'. Use 'jenkinsw context list' to view available contexts.", name)
 	}
 
 	c.CurrentContext = name
@@ -193,13 +323,28 @@ func (c Config) GetConfigFile() (string, error) {
 	return filepath.Join(path, ConfigFile), nil
 }
 
-func (c Context) GetAuthFile() (string, error) {
+// RemoveAuthFile removes the plaintext auth file that earlier versions kept
+// in the context directory.
+func (c Context) RemoveAuthFile() error {
+	dir, err := c.GetContextDir()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(filepath.Join(dir, ".auth")); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (c Context) GetKnownHostsFile() (string, error) {
 	dir, err := c.GetContextDir()
 	if err != nil {
 		return "", err
 	}
 
-	return filepath.Join(dir, ".auth"), nil
+	return filepath.Join(dir, "known_hosts"), nil
 }
 
 func (c Context) GetContextDir() (string, error) {
@@ -211,23 +356,128 @@ func (c Context) GetContextDir() (string, error) {
 	return filepath.Join(dir, "context", c.Name), nil
 }
 
-func (c Context) SaveAuthFile() error {
+// Fields of a context that can be changed with Set
+const (
+	FieldHost        = "host"
+	FieldUsername    = "username"
+	FieldTransport   = "transport"
+	FieldSshEndpoint = "sshEndpoint"
+	FieldSshKeyFile  = "sshKeyFile"
+)
+
+// Fields lists the fields of a context that can be changed with Set
+var Fields = []string{FieldHost, FieldUsername, FieldTransport, FieldSshEndpoint, FieldSshKeyFile}
+
+// Set changes a field of the context. Optional fields are unset by an empty
+// value.
+func (c *Context) Set(field string, value string) error {
+	value = strings.TrimSpace(value)
+
+	switch field {
+	case FieldHost:
+		host, err := NormalizeHost(value)
+		if err != nil {
+			return err
+		}
+		c.Host = host
+	case FieldUsername:
+		if value == "" {
+			return errs.New(errs.KindValidation, "The username cannot be empty")
+		}
+		c.Username = value
+	case FieldTransport:
+		t := *c
+		t.Transport = value
+		if _, err := t.GetTransport(); err != nil {
+			return errs.Wrap(errs.KindValidation, err)
+		}
+		c.Transport = value
+	case FieldSshEndpoint:
+		c.SshEndpoint = value
+	case FieldSshKeyFile:
+		c.SshKeyFile = value
+	default:
+		return errs.New(errs.KindValidation, "Unknown context field '%s'. Valid fields are: %s", field, strings.Join(Fields, ", "))
+	}
+
+	return nil
+}
+
+// RemoveContextDir removes the directory holding the auth file and cached
+// data of the context.
+func (c Context) RemoveContextDir() error {
 	dir, err := c.GetContextDir()
 	if err != nil {
 		return err
 	}
 
-	file, err := c.GetAuthFile()
+	return os.RemoveAll(dir)
+}
+
+// MoveContextDir moves the directory of the context to the directory of
+// the renamed context, replacing any directory left over there.
+func (c Context) MoveContextDir(to Context) error {
+	from, err := c.GetContextDir()
 	if err != nil {
 		return err
 	}
 
-	// Create context directory in case it does not exist
-	if err = os.MkdirAll(dir, 0700); err != nil {
+	dir, err := to.GetContextDir()
+	if err != nil {
 		return err
 	}
 
-	auth := fmt.Sprintf("%s:%s", c.Username, c.ApiToken)
+	if _, err := os.Stat(from); os.IsNotExist(err) {
+		return nil
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return err
+	}
+
+	return os.Rename(from, dir)
+}
+
+// CopyContextDir copies the files in the directory of the context to the
+// directory of another context.
+func (c Context) CopyContextDir(to Context) error {
+	from, err := c.GetContextDir()
+	if err != nil {
+		return err
+	}
+
+	dir, err := to.GetContextDir()
+	if err != nil {
+		return err
+	}
+
+	return filepath.Walk(from, func(path string, info os.FileInfo, err error) error {
+		if os.IsNotExist(err) && path == from {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(from, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dir, rel)
+
+		if info.IsDir() {
+			return os.MkdirAll(target, 0700)
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
 
-	return os.WriteFile(file, []byte(auth), 0600)
+		return os.WriteFile(target, b, info.Mode().Perm())
+	})
 }
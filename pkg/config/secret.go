@@ -0,0 +1,365 @@
+package context
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/crypto/chacha20poly1305"
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// Secret stores holding the API tokens of contexts
+const (
+	// SecretStorePlain keeps the token in the config file, the default
+	SecretStorePlain = "plain"
+
+	// SecretStoreFile keeps the token in a passphrase-encrypted file in the
+	// context directory
+	SecretStoreFile = "file"
+
+	// SecretStoreHelper keeps the token in an external credential helper
+	// speaking the Docker credential helper protocol
+	SecretStoreHelper = "helper"
+)
+
+// SecretStores lists the available secret stores
+var SecretStores = []string{SecretStorePlain, SecretStoreFile, SecretStoreHelper}
+
+// PassphraseEnv is the environment variable holding the passphrase of
+// encrypted secret files
+const PassphraseEnv = "JENKINSW_PASSPHRASE"
+
+// Passphrase returns the passphrase encrypting the secrets of the context.
+// confirm is set when a new secret is encrypted. It defaults to reading
+// PassphraseEnv; commands may replace it to prompt for the passphrase.
+var Passphrase = func(c Context, confirm bool) (string, error) {
+	if p := os.Getenv(PassphraseEnv); p != "" {
+		return p, nil
+	}
+
+	return "", errs.New(errs.KindConfig, "A passphrase is required for the encrypted API token of context '%s'. Please set %s.", c.Name, PassphraseEnv)
+}
+
+// SecretStore stores the API token of a context
+type SecretStore interface {
+	// Get returns the token of the context
+	Get(c Context) (string, error)
+
+	// Set stores the token of the context, which is kept in c.ApiToken only
+	// by the plain store.
+	Set(c *Context, token string) error
+
+	// Delete removes the token of the context
+	Delete(c *Context) error
+}
+
+// GetSecretStore returns the store holding the API token of the context
+func (c Context) GetSecretStore() (SecretStore, error) {
+	switch c.SecretStore {
+	case "", SecretStorePlain:
+		return plainStore{}, nil
+	case SecretStoreFile:
+		return fileStore{}, nil
+	case SecretStoreHelper:
+		if strings.TrimSpace(c.CredentialHelper) == "" {
+			return nil, errs.New(errs.KindConfig, "No 'credentialHelper' set for context '%s' using the %s secret store", c.Name, SecretStoreHelper)
+		}
+		return helperStore{}, nil
+	default:
+		return nil, errs.New(errs.KindConfig, "Unknown secret store '%s' for context '%s'. Valid secret stores are: %s", c.SecretStore, c.Name, strings.Join(SecretStores, ", "))
+	}
+}
+
+// Token returns the API token of the context from its secret store
+func (c Context) Token() (string, error) {
+	store, err := c.GetSecretStore()
+	if err != nil {
+		return "", err
+	}
+
+	return store.Get(c)
+}
+
+// SetToken stores the API token in the secret store of the context, and
+// removes the plaintext auth file left by earlier versions
+func (c *Context) SetToken(token string) error {
+	store, err := c.GetSecretStore()
+	if err != nil {
+		return err
+	}
+
+	if err := store.Set(c, token); err != nil {
+		return err
+	}
+
+	return c.RemoveAuthFile()
+}
+
+// CopySecret copies the token of the context to its copy or renamed
+// context. Tokens in the config file or the context directory are copied
+// along with them, so only credential helpers are updated.
+func (c Context) CopySecret(to *Context) error {
+	if c.SecretStore != SecretStoreHelper || c.SharesSecret(*to) {
+		return nil
+	}
+
+	token, err := c.Token()
+	if err != nil {
+		return err
+	}
+
+	return to.SetToken(token)
+}
+
+// DeleteCopiedSecret removes the token of the context once it was copied to
+// another context with CopySecret and the config no longer refers to the
+// context, e.g. after a rename was saved. Nothing is removed if both
+// contexts share the secret.
+func (c Context) DeleteCopiedSecret(to Context) error {
+	if c.SecretStore != SecretStoreHelper || c.SharesSecret(to) {
+		return nil
+	}
+
+	return helperStore{}.Delete(&c)
+}
+
+// SharesSecret reports whether the token of the context is stored under the
+// same credential helper and server URL as the token of the other context,
+// so removing one removes the other.
+func (c Context) SharesSecret(other Context) bool {
+	if c.SecretStore != SecretStoreHelper || other.SecretStore != SecretStoreHelper {
+		return false
+	}
+
+	return strings.Join(strings.Fields(c.CredentialHelper), " ") == strings.Join(strings.Fields(other.CredentialHelper), " ") &&
+		c.helperServerURL() == other.helperServerURL()
+}
+
+type plainStore struct{}
+
+func (plainStore) Get(c Context) (string, error) {
+	if c.ApiToken == "" {
+		return "", errs.New(errs.KindConfig, "No API token set for context '%s'. Please run 'jenkinsw context add' again.", c.Name)
+	}
+
+	return c.ApiToken, nil
+}
+
+func (plainStore) Set(c *Context, token string) error {
+	c.ApiToken = token
+	return nil
+}
+
+func (plainStore) Delete(c *Context) error {
+	c.ApiToken = ""
+	return nil
+}
+
+const secretFileMagic = "jenkinsw-secret-v1\n"
+
+const (
+	scryptN = 1 << 15
+	scryptR = 8
+	scryptP = 1
+	saltLen = 16
+)
+
+// fileStore encrypts the token with a key derived from a passphrase with
+// scrypt, using XChaCha20-Poly1305.
+type fileStore struct{}
+
+func (c Context) getSecretFile() (string, error) {
+	dir, err := c.GetContextDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "token.enc"), nil
+}
+
+func (fileStore) Get(c Context) (string, error) {
+	f, err := c.getSecretFile()
+	if err != nil {
+		return "", err
+	}
+
+	b, err := os.ReadFile(f)
+	if os.IsNotExist(err) {
+		return "", errs.New(errs.KindConfig, "Encrypted API token %s not found for context '%s'. Please run 'jenkinsw context add' again.", f, c.Name)
+	} else if err != nil {
+		return "", err
+	}
+
+	if !bytes.HasPrefix(b, []byte(secretFileMagic)) || len(b) < len(secretFileMagic)+saltLen+chacha20poly1305.NonceSizeX {
+		return "", errs.New(errs.KindConfig, "Invalid encrypted API token %s", f)
+	}
+	b = b[len(secretFileMagic):]
+	salt, nonce, sealed := b[:saltLen], b[saltLen:saltLen+chacha20poly1305.NonceSizeX], b[saltLen+chacha20poly1305.NonceSizeX:]
+
+	passphrase, err := Passphrase(c, false)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newSecretCipher(passphrase, salt)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := aead.Open(nil, nonce, sealed, nil)
+	if err != nil {
+		return "", errs.New(errs.KindAuth, "Unable to decrypt the API token of context '%s': wrong passphrase", c.Name)
+	}
+
+	return string(token), nil
+}
+
+func (fileStore) Set(c *Context, token string) error {
+	f, err := c.getSecretFile()
+	if err != nil {
+		return err
+	}
+
+	passphrase, err := Passphrase(*c, true)
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltLen)
+	nonce := make([]byte, chacha20poly1305.NonceSizeX)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return err
+	}
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return err
+	}
+
+	aead, err := newSecretCipher(passphrase, salt)
+	if err != nil {
+		return err
+	}
+
+	b := append([]byte(secretFileMagic), salt...)
+	b = append(b, nonce...)
+	b = aead.Seal(b, nonce, []byte(token), nil)
+
+	if err := os.MkdirAll(filepath.Dir(f), 0700); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(f, b, 0600); err != nil {
+		return err
+	}
+
+	c.ApiToken = ""
+
+	return nil
+}
+
+func (fileStore) Delete(c *Context) error {
+	f, err := c.getSecretFile()
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(f); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func newSecretCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, chacha20poly1305.KeySize)
+	if err != nil {
+		return nil, err
+	}
+
+	return chacha20poly1305.NewX(key)
+}
+
+// helperStore runs the credential helper of the context with the Docker
+// credential helper protocol, e.g. docker-credential-secretservice. Tokens
+// are stored under the server URL jenkinsw://<context name>.
+type helperStore struct{}
+
+// helperCredentials is the message of the credential helper protocol
+type helperCredentials struct {
+	ServerURL string `json:"ServerURL"`
+	Username  string `json:"Username"`
+	Secret    string `json:"Secret"`
+}
+
+func (c Context) helperServerURL() string {
+	return "jenkinsw://" + c.Name
+}
+
+// runHelper runs the credential helper with the action, e.g. get, store or
+// erase, passing input on stdin.
+func (c Context) runHelper(action string, input []byte) ([]byte, error) {
+	args := strings.Fields(c.CredentialHelper)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(args[0], append(args[1:], action)...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stdout.String() + " " + stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, errs.New(errs.KindConfig, "Credential helper '%s %s' failed for context '%s': %s", c.CredentialHelper, action, c.Name, msg)
+	}
+
+	return stdout.Bytes(), nil
+}
+
+func (helperStore) Get(c Context) (string, error) {
+	out, err := c.runHelper("get", []byte(c.helperServerURL()))
+	if err != nil {
+		return "", err
+	}
+
+	var creds helperCredentials
+	if err := json.Unmarshal(out, &creds); err != nil {
+		return "", fmt.Errorf("Invalid response of credential helper '%s': %s", c.CredentialHelper, err)
+	}
+
+	if creds.Secret == "" {
+		return "", errs.New(errs.KindConfig, "No API token stored by credential helper '%s' for context '%s'", c.CredentialHelper, c.Name)
+	}
+
+	return creds.Secret, nil
+}
+
+func (helperStore) Set(c *Context, token string) error {
+	input, err := json.Marshal(helperCredentials{ServerURL: c.helperServerURL(), Username: c.Username, Secret: token})
+	if err != nil {
+		return err
+	}
+
+	if _, err := c.runHelper("store", input); err != nil {
+		return err
+	}
+
+	c.ApiToken = ""
+
+	return nil
+}
+
+func (helperStore) Delete(c *Context) error {
+	_, err := c.runHelper("erase", []byte(c.helperServerURL()))
+	return err
+}
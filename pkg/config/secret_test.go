@@ -0,0 +1,265 @@
+package context
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// usePassphrase replaces the passphrase of encrypted secrets for the test
+func usePassphrase(t *testing.T, passphrase string) {
+	t.Helper()
+
+	old := Passphrase
+	t.Cleanup(func() { Passphrase = old })
+
+	Passphrase = func(c Context, confirm bool) (string, error) {
+		return passphrase, nil
+	}
+}
+
+func TestFileStore(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	usePassphrase(t, "correct horse")
+
+	c := Context{Name: "ci", SecretStore: SecretStoreFile, ApiToken: "leftover"}
+	if err := c.SetToken("11a2b3c4d5e6f7"); err != nil {
+		t.Fatal(err)
+	}
+
+	if c.ApiToken != "" {
+		t.Errorf("ApiToken = %q, want it cleared", c.ApiToken)
+	}
+
+	f, err := c.getSecretFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if bytes.Contains(b, []byte("11a2b3c4d5e6f7")) {
+		t.Errorf("%s holds the token in plaintext", f)
+	}
+
+	info, err := os.Stat(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("%s mode = %v, want 0600", f, info.Mode().Perm())
+	}
+
+	token, err := c.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if token != "11a2b3c4d5e6f7" {
+		t.Errorf("Token() = %q, want %q", token, "11a2b3c4d5e6f7")
+	}
+
+	usePassphrase(t, "wrong horse")
+
+	if _, err := c.Token(); errs.KindOf(err) != errs.KindAuth {
+		t.Errorf("Token() with wrong passphrase error = %v, want an auth failure", err)
+	}
+}
+
+func TestFileStoreCorrupted(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	usePassphrase(t, "correct horse")
+
+	c := Context{Name: "ci", SecretStore: SecretStoreFile}
+	if err := c.SetToken("11a2b3c4d5e6f7"); err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := c.getSecretFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b[len(b)-1] ^= 0xff
+	if err := os.WriteFile(f, b, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Token(); err == nil {
+		t.Error("Token() of a tampered file succeeded")
+	}
+
+	if err := os.WriteFile(f, []byte("jenkinsw-secret-v1\nshort"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := c.Token(); errs.KindOf(err) != errs.KindConfig {
+		t.Errorf("Token() of a truncated file error = %v, want an invalid config", err)
+	}
+}
+
+func TestGetSecretStoreBlankHelper(t *testing.T) {
+	for _, helper := range []string{"", " ", "\t"} {
+		c := Context{Name: "ci", SecretStore: SecretStoreHelper, CredentialHelper: helper}
+
+		if _, err := c.GetSecretStore(); errs.KindOf(err) != errs.KindConfig {
+			t.Errorf("GetSecretStore() with helper %q error = %v, want an invalid config", helper, err)
+		}
+
+		if _, err := c.Token(); err == nil {
+			t.Errorf("Token() with helper %q succeeded", helper)
+		}
+	}
+}
+
+// writeAuthFile writes the plaintext auth file of earlier versions for the
+// context, returning its path
+func writeAuthFile(t *testing.T, c Context) string {
+	t.Helper()
+
+	dir, err := c.GetContextDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authFile := filepath.Join(dir, ".auth")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(authFile, []byte("me:11a2b3c4d5e6f7"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return authFile
+}
+
+func TestSetTokenRemovesAuthFile(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	c := Context{Name: "ci", Host: "https://ci.example.com", Username: "me"}
+	authFile := writeAuthFile(t, c)
+
+	config := "contexts:\n- name: ci\n  host: https://ci.example.com\n  username: me\n  apiToken: 11a2b3c4d5e6f7\n"
+	if err := os.WriteFile(filepath.Join(home, ConfigDir, ConfigFile), []byte(config), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	// Reading the config has no side effects
+	if _, err := ReadConfig(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(authFile); err != nil {
+		t.Errorf("ReadConfig() touched the auth file %s: %v", authFile, err)
+	}
+
+	if err := c.SetToken("11a2b3c4d5e6f7"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := os.Stat(authFile); !os.IsNotExist(err) {
+		t.Errorf("legacy auth file %s was not removed: %v", authFile, err)
+	}
+}
+
+// fakeHelper writes a credential helper keeping the secrets in files next
+// to it, returning its command
+func fakeHelper(t *testing.T) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake credential helper is a shell script")
+	}
+
+	script := `#!/bin/sh
+dir="$(dirname "$0")/secrets"
+mkdir -p "$dir"
+input=$(cat)
+case "$1" in
+store) url=$(printf '%s' "$input" | sed 's/.*"ServerURL":"\([^"]*\)".*/\1/') ;;
+*) url=$input ;;
+esac
+file="$dir/$(printf '%s' "$url" | tr -c 'A-Za-z0-9' _)"
+case "$1" in
+store) printf '%s' "$input" > "$file" ;;
+get) cat "$file" 2>/dev/null || { echo "credentials not found in native keychain"; exit 1; } ;;
+erase) rm -f "$file" ;;
+esac
+`
+	helper := filepath.Join(t.TempDir(), "docker-credential-fake")
+	if err := os.WriteFile(helper, []byte(script), 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	return helper
+}
+
+func TestHelperToHelperMigration(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	helper := fakeHelper(t)
+
+	c := Context{Name: "ci", Username: "me", SecretStore: SecretStoreHelper, CredentialHelper: helper}
+	if err := c.SetToken("11a2b3c4d5e6f7"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The same helper with different spacing stores the token under the
+	// same key, so removing the old token would remove the new one
+	updated := c
+	updated.CredentialHelper = "  " + helper + " "
+
+	if !c.SharesSecret(updated) {
+		t.Fatalf("SharesSecret() = false for helpers %q and %q", c.CredentialHelper, updated.CredentialHelper)
+	}
+
+	if err := c.CopySecret(&updated); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteCopiedSecret(updated); err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := updated.Token(); err != nil || token != "11a2b3c4d5e6f7" {
+		t.Errorf("Token() after migration = %q, %v", token, err)
+	}
+
+	// A renamed context has its own key, so the old token is removed
+	renamed := c
+	renamed.Name = "ci-renamed"
+
+	if c.SharesSecret(renamed) {
+		t.Errorf("SharesSecret() = true for contexts %s and %s", c.Name, renamed.Name)
+	}
+
+	if err := c.CopySecret(&renamed); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.DeleteCopiedSecret(renamed); err != nil {
+		t.Fatal(err)
+	}
+
+	if token, err := renamed.Token(); err != nil || token != "11a2b3c4d5e6f7" {
+		t.Errorf("Token() of the renamed context = %q, %v", token, err)
+	}
+
+	if _, err := c.Token(); err == nil {
+		t.Errorf("Token() of context %s succeeded after its token was deleted", c.Name)
+	}
+}
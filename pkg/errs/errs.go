@@ -0,0 +1,154 @@
+package errs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+
+	"github.com/fatih/color"
+)
+
+// Kind classifies an error for scripts calling jenkinsw
+type Kind string
+
+const (
+	KindUnknown    Kind = "error"
+	KindValidation Kind = "validation_failed"
+	KindConfig     Kind = "config_invalid"
+	KindNotFound   Kind = "not_found"
+	KindAuth       Kind = "auth_failure"
+	KindNetwork    Kind = "network"
+	KindTimeout    Kind = "timeout"
+	KindCanceled   Kind = "canceled"
+)
+
+// Exit codes of jenkinsw. Codes 1 to 3 are not used for errors, since
+// commands waiting for a build exit with 1, 2 or 3 for a build that failed,
+// was unstable or was aborted.
+const (
+	ExitOK         = 0
+	ExitValidation = 4
+	ExitConfig     = 5
+	ExitNotFound   = 6
+	ExitAuth       = 7
+	ExitNetwork    = 8
+	ExitUnknown    = 9
+	ExitTimeout    = 124
+	ExitCanceled   = 130
+)
+
+var exitCodes = map[Kind]int{
+	KindUnknown:    ExitUnknown,
+	KindValidation: ExitValidation,
+	KindConfig:     ExitConfig,
+	KindNotFound:   ExitNotFound,
+	KindAuth:       ExitAuth,
+	KindNetwork:    ExitNetwork,
+	KindTimeout:    ExitTimeout,
+	KindCanceled:   ExitCanceled,
+}
+
+// Error is an error of a known kind
+type Error struct {
+	kind Kind
+	err  error
+}
+
+func (e *Error) Error() string {
+	return e.err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+func (e *Error) Kind() Kind {
+	return e.kind
+}
+
+// New returns an error of the kind with a formatted message
+func New(kind Kind, format string, args ...interface{}) error {
+	return &Error{kind: kind, err: fmt.Errorf(format, args...)}
+}
+
+// Wrap marks err as an error of the kind. It returns nil if err is nil.
+func Wrap(kind Kind, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &Error{kind: kind, err: err}
+}
+
+// ExitError ends a command with an exit code without reporting an error,
+// e.g. to reflect the result of a build.
+type ExitError struct {
+	Code int
+}
+
+func (e *ExitError) Error() string {
+	return fmt.Sprintf("exit status %d", e.Code)
+}
+
+// KindOf returns the kind of the outermost error in the chain that has one.
+// Timeouts, canceled contexts and network errors are classified without being
+// wrapped.
+func KindOf(err error) Kind {
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if k, ok := e.(interface{ Kind() Kind }); ok {
+			return k.Kind()
+		}
+	}
+
+	if errors.Is(err, context.DeadlineExceeded) {
+		return KindTimeout
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return KindCanceled
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return KindNetwork
+	}
+
+	return KindUnknown
+}
+
+// ExitCode returns the exit code for the error, or 0 if err is nil
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	return exitCodes[KindOf(err)]
+}
+
+// Report writes the error to w, as a JSON object if asJSON is set. Errors
+// carrying only an exit code are not reported.
+func Report(w io.Writer, err error, asJSON bool) {
+	var exitErr *ExitError
+	if err == nil || errors.As(err, &exitErr) {
+		return
+	}
+
+	if asJSON {
+		json.NewEncoder(w).Encode(struct {
+			Error    string `json:"error"`
+			Kind     Kind   `json:"kind"`
+			ExitCode int    `json:"exitCode"`
+		}{err.Error(), KindOf(err), ExitCode(err)})
+		return
+	}
+
+	color.New(color.FgRed).Fprintf(w, "Error: %s\n", err)
+}
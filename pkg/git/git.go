@@ -0,0 +1,92 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// run executes a git command and returns its trimmed standard output
+func run(args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+
+	out, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return "", err
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
+
+// CurrentBranch returns the name of the branch checked out in the working directory
+func CurrentBranch() (string, error) {
+	if _, err := run("rev-parse", "--git-dir"); err != nil {
+		return "", err
+	}
+
+	branch, err := run("symbolic-ref", "--short", "-q", "HEAD")
+	if err != nil || branch == "" {
+		return "", errs.New(errs.KindValidation, "Not on a branch (detached HEAD). Please check out a branch or specify one with --branch.")
+	}
+
+	return branch, nil
+}
+
+// TopLevel returns the root directory of the current git repository
+func TopLevel() (string, error) {
+	return run("rev-parse", "--show-toplevel")
+}
+
+// Remotes returns the distinct URLs of the remotes of the current repository
+func Remotes() ([]string, error) {
+	out, err := run("remote", "-v")
+	if err != nil {
+		return nil, err
+	}
+
+	var urls []string
+	seen := map[string]bool{}
+
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || seen[fields[1]] {
+			continue
+		}
+
+		seen[fields[1]] = true
+		urls = append(urls, fields[1])
+	}
+
+	return urls, nil
+}
+
+// NormalizeRemote reduces ssh, https and scp-like remote URLs to the common
+// form "host/owner/repo" so that different URLs of one repository compare equal.
+func NormalizeRemote(remote string) string {
+	r := strings.TrimSpace(remote)
+
+	if i := strings.Index(r, "://"); i >= 0 {
+		r = r[i+3:]
+	} else if i := strings.Index(r, ":"); i >= 0 && !strings.Contains(r[:i], "/") {
+		// scp-like syntax: user@host:owner/repo
+		r = r[:i] + "/" + r[i+1:]
+	}
+
+	if i := strings.Index(r, "@"); i >= 0 && i < strings.Index(r+"/", "/") {
+		r = r[i+1:]
+	}
+
+	host, path, _ := strings.Cut(r, "/")
+	if h, _, ok := strings.Cut(host, ":"); ok {
+		host = h
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	return strings.ToLower(host) + "/" + strings.ToLower(path)
+}
@@ -0,0 +1,136 @@
+package jenkins
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+type Artifact struct {
+	FileName     string `json:"fileName"`
+	RelativePath string `json:"relativePath"`
+
+	// Hash is the MD5 fingerprint Jenkins recorded for the artifact, empty
+	// if the artifact was not fingerprinted
+	Hash string `json:"hash,omitempty"`
+}
+
+// ListArtifacts returns the archived artifacts of a build, with their
+// fingerprints where available.
+func (c *Client) ListArtifacts(ctx context.Context, job string, build string) ([]Artifact, error) {
+	var resp struct {
+		Artifacts   []Artifact `json:"artifacts"`
+		Fingerprint []struct {
+			FileName string `json:"fileName"`
+			Hash     string `json:"hash"`
+		} `json:"fingerprint"`
+	}
+
+	query := url.Values{"tree": {"artifacts[fileName,relativePath],fingerprint[fileName,hash]"}}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/%s/api/json", JobUrlPath(job), BuildRef(build)), query, &resp); err != nil {
+		return nil, err
+	}
+
+	// Fingerprints only record file names, so ambiguous names are skipped
+	hashes := map[string]string{}
+	for _, f := range resp.Fingerprint {
+		if _, ok := hashes[f.FileName]; ok {
+			hashes[f.FileName] = ""
+		} else {
+			hashes[f.FileName] = f.Hash
+		}
+	}
+
+	for i, a := range resp.Artifacts {
+		resp.Artifacts[i].Hash = hashes[a.FileName]
+	}
+
+	return resp.Artifacts, nil
+}
+
+// DownloadArtifact downloads an artifact of a build to path, resuming a
+// previously interrupted download, and verifies it against its fingerprint.
+func (c *Client) DownloadArtifact(ctx context.Context, job string, build string, artifact Artifact, path string, progress func(int64, int64)) error {
+	opts := c.artifactDownloadOptions()
+	opts.Resume = true
+	opts.Progress = progress
+
+	if err := Download(ctx, path, c.artifactUrl(job, build, artifact), opts); err != nil {
+		return fmt.Errorf("Failed to download %s: %w", artifact.RelativePath, err)
+	}
+
+	if err := artifact.Verify(path); err != nil {
+		os.Remove(path)
+		return err
+	}
+
+	return nil
+}
+
+// IsArtifactCurrent reports whether the file at path holds the artifact of
+// the build. Fingerprinted artifacts are checked against their fingerprint,
+// others against the size and modification time Jenkins reports for them.
+func (c *Client) IsArtifactCurrent(ctx context.Context, job string, build string, artifact Artifact, path string) (bool, error) {
+	if artifact.Hash != "" {
+		if _, err := os.Stat(path); err != nil {
+			return false, nil
+		}
+
+		return artifact.Verify(path) == nil, nil
+	}
+
+	current, err := IsCurrent(ctx, path, c.artifactUrl(job, build, artifact), c.artifactDownloadOptions())
+	if err != nil {
+		return false, fmt.Errorf("Failed to check %s: %w", artifact.RelativePath, err)
+	}
+
+	return current, nil
+}
+
+func (c *Client) artifactUrl(job string, build string, artifact Artifact) string {
+	var segments []string
+	for _, s := range strings.Split(artifact.RelativePath, "/") {
+		segments = append(segments, url.PathEscape(s))
+	}
+
+	return fmt.Sprintf("%s%s/%s/artifact/%s", c.api.Server, JobUrlPath(job), BuildRef(build), strings.Join(segments, "/"))
+}
+
+func (c *Client) artifactDownloadOptions() DownloadOptions {
+	opts := DownloadOptions{Client: c.api.Requester.Client}
+	if auth := c.api.Requester.BasicAuth; auth != nil {
+		opts.Username, opts.Password = auth.Username, auth.Password
+	}
+
+	return opts
+}
+
+// Verify checks a downloaded artifact against its fingerprint. Artifacts
+// without a fingerprint are not checked.
+func (a Artifact) Verify(path string) error {
+	if a.Hash == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := md5.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, a.Hash) {
+		return fmt.Errorf("Fingerprint mismatch for %s: expected %s, got %s", a.RelativePath, a.Hash, sum)
+	}
+
+	return nil
+}
@@ -0,0 +1,214 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Build results
+const (
+	ResultSuccess  = "SUCCESS"
+	ResultFailure  = "FAILURE"
+	ResultUnstable = "UNSTABLE"
+	ResultAborted  = "ABORTED"
+	ResultNotBuilt = "NOT_BUILT"
+)
+
+// PollInterval is how often queue items and builds are polled while waiting
+var PollInterval = time.Second
+
+type BuildStatus struct {
+	Number   int64  `json:"number"`
+	Url      string `json:"url"`
+	Building bool   `json:"building"`
+	Result   string `json:"result"`
+}
+
+type QueueItem struct {
+	Id           int64  `json:"id"`
+	Why          string `json:"why"`
+	Cancelled    bool   `json:"cancelled"`
+	InQueueSince int64  `json:"inQueueSince"`
+	Task         struct {
+		Name string `json:"name"`
+		Url  string `json:"url"`
+	} `json:"task"`
+	Executable *struct {
+		Number int64  `json:"number"`
+		Url    string `json:"url"`
+	} `json:"executable"`
+}
+
+// TriggerBuild queues a build of the job with the given parameters and
+// returns the ID of the queue item. Values of file parameters are paths of
+// local files, which are uploaded with the request.
+func (c *Client) TriggerBuild(ctx context.Context, job string, defs []ParameterDefinition, params map[string]string) (int64, error) {
+	endpoint := JobUrlPath(job) + "/build"
+
+	var body io.Reader
+	var contentType string
+
+	if len(defs) > 0 {
+		endpoint = JobUrlPath(job) + "/buildWithParameters"
+
+		var err error
+		if body, contentType, err = encodeParameters(defs, params); err != nil {
+			return 0, err
+		}
+	}
+
+	req, err := c.newPostRequest(ctx, endpoint, nil, contentType, body)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return 0, fmt.Errorf("Jenkins did not return a queue item for the build of %s", job)
+	}
+
+	u, err := url.Parse(location)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.ParseInt(path.Base(u.Path), 10, 64)
+}
+
+// encodeParameters encodes the parameters as a form, or as a multipart form
+// if any file parameters are set.
+func encodeParameters(defs []ParameterDefinition, params map[string]string) (io.Reader, string, error) {
+	files := map[string]bool{}
+	for _, d := range defs {
+		if _, ok := params[d.Name]; ok && d.Kind() == ParamFile {
+			files[d.Name] = true
+		}
+	}
+
+	if len(files) == 0 {
+		form := url.Values{}
+		for name, value := range params {
+			form.Set(name, value)
+		}
+
+		return strings.NewReader(form.Encode()), "application/x-www-form-urlencoded", nil
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+
+	for name, value := range params {
+		if !files[name] {
+			if err := w.WriteField(name, value); err != nil {
+				return nil, "", err
+			}
+			continue
+		}
+
+		f, err := os.Open(value)
+		if err != nil {
+			return nil, "", err
+		}
+
+		part, err := w.CreateFormFile(name, filepath.Base(value))
+		if err == nil {
+			_, err = io.Copy(part, f)
+		}
+		f.Close()
+
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return &buf, w.FormDataContentType(), nil
+}
+
+// GetQueueItem returns the state of a queue item
+func (c *Client) GetQueueItem(ctx context.Context, id int64) (QueueItem, error) {
+	var item QueueItem
+	err := c.getJSON(ctx, fmt.Sprintf("/queue/item/%d/api/json", id), nil, &item)
+
+	return item, err
+}
+
+// WaitForQueueItem waits until the queue item starts a build and returns
+// the build number.
+func (c *Client) WaitForQueueItem(ctx context.Context, id int64) (int64, error) {
+	for {
+		item, err := c.GetQueueItem(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		if item.Cancelled {
+			return 0, fmt.Errorf("Queue item %d was cancelled", id)
+		}
+
+		if item.Executable != nil && item.Executable.Number > 0 {
+			return item.Executable.Number, nil
+		}
+
+		if err := sleep(ctx, PollInterval); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// GetBuildStatus returns the state of a build, defaulting to the last build
+func (c *Client) GetBuildStatus(ctx context.Context, job string, build string) (BuildStatus, error) {
+	var status BuildStatus
+
+	query := url.Values{"tree": {"number,url,building,result"}}
+	err := c.getJSON(ctx, fmt.Sprintf("%s/%s/api/json", JobUrlPath(job), BuildRef(build)), query, &status)
+
+	return status, err
+}
+
+// WaitForBuild waits until the build finishes and returns its final state
+func (c *Client) WaitForBuild(ctx context.Context, job string, number int64) (BuildStatus, error) {
+	for {
+		status, err := c.GetBuildStatus(ctx, job, strconv.FormatInt(number, 10))
+		if err != nil {
+			return status, err
+		}
+
+		if !status.Building && status.Result != "" {
+			return status, nil
+		}
+
+		if err := sleep(ctx, PollInterval); err != nil {
+			return status, err
+		}
+	}
+}
+
+// sleep waits for the duration, returning early if ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(d):
+		return nil
+	}
+}
@@ -1,27 +1,31 @@
 package jenkins
 
 import (
-	"archive/zip"
+	"context"
 	"fmt"
 	"io"
-	"net/http"
-	"bufio"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 
 	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
 )
 
+// killGracePeriod is how long an interrupted CLI process may take to exit
+const killGracePeriod = 5 * time.Second
+
 type JenkinsCli struct {
 	ctx       *config.Context
+	ioStreams *utils.IOStreams
 }
 
-func NewJenkinsCli(ctx *config.Context) JenkinsCli {
-	return JenkinsCli{ctx: ctx}
+func NewJenkinsCli(ctx *config.Context, streams *utils.IOStreams) JenkinsCli {
+	return JenkinsCli{ctx: ctx, ioStreams: streams}
 }
 
 func (c JenkinsCli) GetCliDir() (string, error) {
@@ -36,132 +40,115 @@ func (c JenkinsCli) GetCliDir() (string, error) {
 	return filepath.Join(configDir, "cli", hostDir), nil
 }
 
-func (c JenkinsCli) GetCliPath() (string, error) {
-	dir, err := c.GetCliDir()
+// RunCommand executes a CLI command with the transport selected by the
+// context, streaming its output to the CLI's streams. A *CliError is
+// returned if the command exits with a non-zero status.
+func (c JenkinsCli) RunCommand(ctx context.Context, args []string, stdin io.Reader) error {
+	transport, err := NewTransport(c.ctx, c.ioStreams)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return filepath.Join(dir, "cli.jar"), nil
-}
-
-func (c JenkinsCli) DownloadCliJar() error {
-	jenkinsJarUrl := fmt.Sprintf("%s/jnlpJars/jenkins-cli.jar", c.ctx.Host)
+	log.Debug("Running CLI command: ", strings.Join(args, " "))
 
-	dir, err := c.GetCliDir()
+	code, err := transport.Run(ctx, args, stdin, c.ioStreams.Out, c.ioStreams.ErrOut)
 	if err != nil {
-			return err
+		return err
 	}
 
-	path, _ := c.GetCliPath()
-
-	fmt.Printf("Downloading Jenkins CLI from %s to %s\n", jenkinsJarUrl, path)
-
-	// Create CLI directory
-	if err = os.MkdirAll(dir, 0700); err != nil {
-			return err
+	if code != 0 {
+		return &CliError{Command: args[0], ExitCode: code}
 	}
 
-	// Download CLI jar file from Jenkins host
-	return Download(path, jenkinsJarUrl)
+	return nil
 }
 
-func Download(filepath string, url string) (err error) {
-	out, err := os.Create(filepath)
+// writeAuthFile writes the credentials of the context to a temporary file
+// for the -auth option of the CLI jar. The caller removes the file.
+func (c JenkinsCli) writeAuthFile() (string, error) {
+	token, err := c.ctx.Token()
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer out.Close()
 
-	resp, err := http.Get(url)
+	f, err := os.CreateTemp("", "jenkinsw-auth-")
 	if err != nil {
-		return err
+		return "", err
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("request failed with status: %s", resp.Status)
+	_, err = fmt.Fprintf(f, "%s:%s", c.ctx.Username, token)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
 	}
-
-	_, err = io.Copy(out, resp.Body)
 	if err != nil {
-		return err
+		os.Remove(f.Name())
+		return "", err
 	}
 
-	return nil
+	return f.Name(), nil
 }
 
-func (c JenkinsCli) RunCommand(subcommand string) (out []byte, err error) {
-	cli, err := c.GetCliPath()
-	if err != nil {
-		return nil, err
-	}
+// jarTransport runs CLI commands with the official CLI jar
+type jarTransport struct {
+	cli JenkinsCli
+}
 
-	authFile, err := c.ctx.GetAuthFile()
-	if err != nil {
-		return nil, err
+func (t jarTransport) Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	if err := t.cli.Refresh(ctx); err != nil {
+		return -1, err
 	}
 
-	if _, err := os.Stat(authFile); err != nil {
-		return nil, fmt.Errorf("Authentication file not present for context '%s'. Please run 'jenkinsw context add' again.", c.ctx.Name)
+	cli, err := t.cli.GetCliPath()
+	if err != nil {
+		return -1, err
 	}
 
-	command := fmt.Sprintf("java -jar '%s' -s '%s' -auth '@%s' -webSocket %s", cli, c.ctx.Host, authFile, subcommand)
-
-	cmd := exec.Command("sh", "-c", command)
-
-	log.Debug("Running command:", command)
-	out, err = cmd.CombinedOutput()
-
-	return out, err
-}
-
-func (c JenkinsCli) Version() (version string, err error) {
-	path, err := c.GetCliPath()
+	authFile, err := t.cli.writeAuthFile()
 	if err != nil {
-		return "", err
+		return -1, err
 	}
+	defer os.Remove(authFile)
 
-	archive, err := zip.OpenReader(path)
-    if err != nil {
-        return "", err
-    }
-    defer archive.Close()
+	cmd := exec.Command("java", append([]string{"-jar", cli, "-s", t.cli.ctx.Host, "-auth", "@" + authFile, "-webSocket"}, args...)...)
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
-	for _, f := range archive.File {
-		if f.Name != "META-INF/MANIFEST.MF" {
-			continue
-		}
+	if err := cmd.Start(); err != nil {
+		return -1, err
+	}
 
-        manifestFile, err := f.Open()
-        if err != nil {
-            return "", err
-        }
-        defer manifestFile.Close()
-
-		content := new(strings.Builder)
-        if _, err := io.Copy(content, manifestFile); err != nil {
-            return "", err
-        }
-
-		version := "unknown"
-		scanner := bufio.NewScanner(strings.NewReader(content.String()))
-		for scanner.Scan() {
-			line := scanner.Text()
-			if strings.HasPrefix(line, "Jenkins-CLI-Version:") {
-				words := strings.Fields(line)
-				if len(words) == 2 {
-					version = words[1]
-				}
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-ctx.Done():
+			// Forward the interrupt to java, and kill it if it does not exit in time
+			log.Debug("Interrupting CLI process ", cmd.Process.Pid)
+			cmd.Process.Signal(os.Interrupt)
+
+			select {
+			case <-done:
+			case <-time.After(killGracePeriod):
+				cmd.Process.Kill()
 			}
+		case <-done:
 		}
+	}()
 
-		if err := scanner.Err(); err != nil {
-			return "", err
-		}
+	err = cmd.Wait()
+	close(done)
+
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
 
-        return version, nil
-    }
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		return exitErr.ExitCode(), nil
+	}
+
+	if err != nil {
+		return -1, err
+	}
 
-	return "unknown", nil
+	return 0, nil
 }
@@ -3,8 +3,11 @@ package jenkins
 import (
 	"context"
 	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/bndr/gojenkins"
 
@@ -15,32 +18,149 @@ import (
 type Client struct {
 	ioStreams *utils.IOStreams
 	api       *gojenkins.Jenkins
-	ctx       context.Context
 }
 
-func NewClient(ctx *config.Context, streams *utils.IOStreams) (*Client, error) {
-	httpClient := http.DefaultClient
-	httpClient.Transport = http.DefaultTransport
-	httpClient.Transport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: false}
+// NewClient connects to the Jenkins server of the context. Requests are
+// canceled when httpCtx is done.
+func NewClient(httpCtx context.Context, ctx *config.Context, streams *utils.IOStreams) (*Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: false}
 
-	httpCtx := context.TODO()
-	jenkins := gojenkins.CreateJenkins(nil, ctx.Host, ctx.Username, ctx.ApiToken)
-	_, err := jenkins.Init(httpCtx)
+	httpClient := &http.Client{Transport: contextTransport{ctx: httpCtx, base: transport}}
 
+	token, err := ctx.Token()
 	if err != nil {
 		return nil, err
 	}
 
-	return &Client{api: jenkins, ctx: httpCtx, ioStreams: streams}, nil
+	jenkins := gojenkins.CreateJenkins(httpClient, ctx.Host, ctx.Username, token)
+	c := &Client{api: jenkins, ioStreams: streams}
+
+	if _, err := jenkins.Init(httpCtx); err != nil {
+		// gojenkins does not tell why it failed, so ask again to find out
+		if probeErr := c.getJSON(httpCtx, "/api/json", url.Values{"tree": {"mode"}}, &struct{}{}); probeErr != nil {
+			return nil, probeErr
+		}
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// contextTransport binds requests without a context to the given context,
+// since gojenkins does not pass its context on to the requests it sends.
+type contextTransport struct {
+	ctx  context.Context
+	base http.RoundTripper
+}
+
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Context() == context.Background() {
+		req = req.WithContext(t.ctx)
+	}
+
+	return t.base.RoundTrip(req)
 }
 
 func (c *Client) Version() string {
 	return c.api.Version
 }
 
+// newRequest creates an authenticated request for a path relative to the Jenkins host
+func (c *Client) newRequest(ctx context.Context, method string, path string, query url.Values, body io.Reader) (*http.Request, error) {
+	u := c.api.Server + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if auth := c.api.Requester.BasicAuth; auth != nil {
+		req.SetBasicAuth(auth.Username, auth.Password)
+	}
+
+	return req, nil
+}
+
+// newPostRequest creates an authenticated POST request carrying the CSRF
+// crumb, if the server issues crumbs.
+func (c *Client) newPostRequest(ctx context.Context, path string, query url.Values, contentType string, body io.Reader) (*http.Request, error) {
+	req, err := c.newRequest(ctx, "POST", path, query, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	var crumb struct {
+		Crumb             string `json:"crumb"`
+		CrumbRequestField string `json:"crumbRequestField"`
+	}
+
+	if err := c.getJSON(ctx, "/crumbIssuer/api/json", nil, &crumb); err == nil && crumb.CrumbRequestField != "" {
+		req.Header.Set(crumb.CrumbRequestField, crumb.Crumb)
+	}
+
+	return req, nil
+}
+
+// getJSON decodes the JSON response of a GET request into v
+func (c *Client) getJSON(ctx context.Context, path string, query url.Values, v interface{}) error {
+	req, err := c.newRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// do sends a request and returns an error for any non-2xx response
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	resp, err := c.api.Requester.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		resp.Body.Close()
+		return nil, &HTTPError{Path: req.URL.Path, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	return resp, nil
+}
+
+// GetJobConfig returns the config.xml of a job
+func (c *Client) GetJobConfig(ctx context.Context, fullName string) (string, error) {
+	req, err := c.newRequest(ctx, "GET", JobUrlPath(fullName)+"/config.xml", nil, nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+
+	return string(b), err
+}
+
 // ListJobs details
-func (c *Client) ListJobs(depth int) ([]gojenkins.InnerJob, error) {
-	immediateJobs, err := c.api.GetAllJobNames(c.ctx)
+func (c *Client) ListJobs(ctx context.Context, depth int) ([]gojenkins.InnerJob, error) {
+	immediateJobs, err := c.api.GetAllJobNames(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -49,12 +169,12 @@ func (c *Client) ListJobs(depth int) ([]gojenkins.InnerJob, error) {
 	}
 	finalJobs := make([]gojenkins.InnerJob, 0)
 	for _, jobRaw := range immediateJobs {
-		if jobRaw.Class == "com.cloudbees.hudson.plugins.folder.Folder" {
-			job, err := c.api.GetJob(c.ctx, jobRaw.Name)
+		if IsFolder(jobRaw.Class) {
+			job, err := c.api.GetJob(ctx, jobRaw.Name)
 			if err != nil {
 				return nil, err
 			}
-			receivedJobs, err := c.getInnerJobs(job.GetName(), job, 0, depth)
+			receivedJobs, err := c.getInnerJobs(ctx, job.GetName(), job, 0, depth)
 			if err != nil {
 				return nil, err
 			}
@@ -68,7 +188,7 @@ func (c *Client) ListJobs(depth int) ([]gojenkins.InnerJob, error) {
 	return finalJobs, nil
 }
 
-func (jc *Client) getInnerJobs(parent string, job *gojenkins.Job, depth, limit int) ([]gojenkins.InnerJob, error) {
+func (jc *Client) getInnerJobs(ctx context.Context, parent string, job *gojenkins.Job, depth, limit int) ([]gojenkins.InnerJob, error) {
 	if depth == limit {
 		if job == nil {
 			return nil, nil
@@ -79,14 +199,14 @@ func (jc *Client) getInnerJobs(parent string, job *gojenkins.Job, depth, limit i
 	}
 	finalJobs := make([]gojenkins.InnerJob, 0)
 
-	jobsList, err := job.GetInnerJobs(jc.ctx)
+	jobsList, err := job.GetInnerJobs(ctx)
 	if err != nil {
 		return nil, err
 	}
 	for _, jobInner := range jobsList {
 		jobName := fmt.Sprintf("%s/job/%s", parent, jobInner.GetName())
-		if jobInner.GetDetails().Class == "com.cloudbees.hudson.plugins.folder.Folder" {
-			receivedJobs, err := jc.getInnerJobs(jobName, jobInner, depth+1, limit)
+		if IsFolder(jobInner.GetDetails().Class) {
+			receivedJobs, err := jc.getInnerJobs(ctx, jobName, jobInner, depth+1, limit)
 			if err != nil {
 				return nil, err
 			}
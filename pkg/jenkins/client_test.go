@@ -0,0 +1,19 @@
+package jenkins
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/bndr/gojenkins"
+)
+
+// newTestClient returns a client for a stand-in Jenkins server serving handler
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	return &Client{api: gojenkins.CreateJenkins(srv.Client(), srv.URL, "user", "token")}
+}
@@ -0,0 +1,239 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// partialSuffix is appended to files while they are downloaded
+const partialSuffix = ".part"
+
+type DownloadOptions struct {
+	// Client sends the request, defaulting to http.DefaultClient
+	Client *http.Client
+
+	// Username and Password authenticate the request if set
+	Username string
+	Password string
+
+	// Resume continues an interrupted download from its partial file,
+	// which is kept if the download fails. Partial files are only resumed
+	// if the server reports the same Last-Modified time as when they were
+	// started, and downloaded again otherwise.
+	Resume bool
+
+	// Progress is called with the bytes downloaded so far and the total
+	// size, or -1 if the size is unknown
+	Progress func(downloaded int64, total int64)
+}
+
+// Download fetches url into path. The file is written to path + ".part"
+// and renamed once complete, so path never holds a partial download. The
+// modification time of the file is set to the Last-Modified time reported
+// by the server, if any.
+func Download(ctx context.Context, path string, url string, opts DownloadOptions) error {
+	part := path + partialSuffix
+
+	var offset int64
+	var modified time.Time
+	if opts.Resume {
+		if info, err := os.Stat(part); err == nil {
+			offset, modified = info.Size(), info.ModTime()
+		}
+	}
+
+	err := download(ctx, path, url, offset, modified, opts)
+	if err == errStalePartial {
+		os.Remove(part)
+		err = download(ctx, path, url, 0, time.Time{}, opts)
+	}
+
+	return err
+}
+
+// errStalePartial is returned if a partial file cannot be resumed since the
+// file on the server is not the one it was started from
+var errStalePartial = fmt.Errorf("partial download is stale")
+
+// download fetches url into the partial file of path, resuming from offset
+// if the file on the server was last modified at modified.
+func download(ctx context.Context, path string, url string, offset int64, modified time.Time, opts DownloadOptions) (err error) {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	part := path + partialSuffix
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return err
+	}
+
+	if opts.Username != "" {
+		req.SetBasicAuth(opts.Username, opts.Password)
+	}
+
+	if offset > 0 {
+		req.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		req.Header.Set("If-Range", modified.UTC().Format(http.TimeFormat))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	flags := os.O_CREATE | os.O_WRONLY
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		offset = 0
+		flags |= os.O_TRUNC
+	case http.StatusPartialContent:
+		if offset == 0 || !sameTime(lastModified, modified) {
+			return errStalePartial
+		}
+		flags |= os.O_APPEND
+	case http.StatusRequestedRangeNotSatisfiable:
+		if offset == 0 {
+			return &HTTPError{Path: req.URL.Path, StatusCode: resp.StatusCode, Status: resp.Status}
+		}
+
+		// The partial file is complete if it has the size of the file on
+		// the server, and was started from the same file
+		if contentRangeSize(resp.Header.Get("Content-Range")) != offset || !sameTime(lastModified, modified) {
+			return errStalePartial
+		}
+
+		return os.Rename(part, path)
+	default:
+		return &HTTPError{Path: req.URL.Path, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	out, err := os.OpenFile(part, flags, 0644)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil && !opts.Resume {
+			os.Remove(part)
+		}
+	}()
+
+	total := int64(-1)
+	if resp.ContentLength >= 0 {
+		total = offset + resp.ContentLength
+	}
+
+	var w io.Writer = out
+	if opts.Progress != nil {
+		w = &progressWriter{w: out, written: offset, total: total, progress: opts.Progress}
+		opts.Progress(offset, total)
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+
+	// Partial files keep the Last-Modified time of the server, so they are
+	// only resumed from the same file
+	if !lastModified.IsZero() {
+		if chErr := os.Chtimes(part, lastModified, lastModified); err == nil {
+			err = chErr
+		}
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(part, path)
+}
+
+// sameTime reports whether the Last-Modified time of the server is known and
+// matches a file modification time, which HTTP dates give in seconds
+func sameTime(lastModified time.Time, modified time.Time) bool {
+	return !lastModified.IsZero() && lastModified.Unix() == modified.Unix()
+}
+
+// contentRangeSize returns the complete length of a Content-Range header like
+// "bytes */1234", or -1 if it is unknown
+func contentRangeSize(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+
+	return size
+}
+
+// progressWriter reports the bytes written through it
+type progressWriter struct {
+	w        io.Writer
+	written  int64
+	total    int64
+	progress func(int64, int64)
+}
+
+func (p *progressWriter) Write(b []byte) (int, error) {
+	n, err := p.w.Write(b)
+	p.written += int64(n)
+	p.progress(p.written, p.total)
+
+	return n, err
+}
+
+// IsCurrent reports whether the file at path is the one served at url, as
+// downloaded by Download: it must have the size and the Last-Modified time
+// the server reports. Files are never current if the server reports neither.
+func IsCurrent(ctx context.Context, path string, url string, opts DownloadOptions) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, nil
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", url, nil)
+	if err != nil {
+		return false, err
+	}
+
+	if opts.Username != "" {
+		req.SetBasicAuth(opts.Username, opts.Password)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, &HTTPError{Path: req.URL.Path, StatusCode: resp.StatusCode, Status: resp.Status}
+	}
+
+	lastModified, _ := http.ParseTime(resp.Header.Get("Last-Modified"))
+
+	return resp.ContentLength == info.Size() && sameTime(lastModified, info.ModTime()), nil
+}
@@ -0,0 +1,211 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	downloadContent  = []byte(strings.Repeat("0123456789", 100))
+	downloadModified = time.Date(2022, 5, 1, 12, 0, 0, 0, time.UTC)
+)
+
+// downloadServer serves downloadContent like Jenkins serves artifacts and
+// records the Range headers of the requests
+type downloadServer struct {
+	mu     sync.Mutex
+	ranges []string
+
+	// modified is the Last-Modified time served, defaulting to
+	// downloadModified
+	modified time.Time
+
+	// ignoreIfRange serves ranges even if the file changed
+	ignoreIfRange bool
+}
+
+func (s *downloadServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.ranges = append(s.ranges, r.Header.Get("Range"))
+	s.mu.Unlock()
+
+	if s.ignoreIfRange {
+		r.Header.Del("If-Range")
+	}
+
+	modified := s.modified
+	if modified.IsZero() {
+		modified = downloadModified
+	}
+
+	http.ServeContent(w, r, "app.jar", modified, bytes.NewReader(downloadContent))
+}
+
+func (s *downloadServer) start(t *testing.T) string {
+	t.Helper()
+
+	srv := httptest.NewServer(s)
+	t.Cleanup(srv.Close)
+
+	return srv.URL + "/app.jar"
+}
+
+// writePartial leaves a partial download of path modified at modified
+func writePartial(t *testing.T, path string, data []byte, modified time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path+partialSuffix, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(path+partialSuffix, modified, modified); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func checkDownload(t *testing.T, path string) {
+	t.Helper()
+
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, downloadContent) {
+		t.Errorf("downloaded %q, want %q", got, downloadContent)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !info.ModTime().Equal(downloadModified) {
+		t.Errorf("modification time = %s, want %s", info.ModTime(), downloadModified)
+	}
+
+	if _, err := os.Stat(path + partialSuffix); !os.IsNotExist(err) {
+		t.Errorf("partial file was not removed: %v", err)
+	}
+}
+
+func TestDownload(t *testing.T) {
+	tests := []struct {
+		name          string
+		ignoreIfRange bool
+		partial       []byte
+		// modified is the modification time of the partial file
+		modified time.Time
+		// ranges are the Range headers expected to be sent
+		ranges []string
+	}{
+		{
+			name:   "no partial file",
+			ranges: []string{""},
+		},
+		{
+			name:     "resume",
+			partial:  downloadContent[:400],
+			modified: downloadModified,
+			ranges:   []string{"bytes=400-"},
+		},
+		{
+			name:     "stale partial file",
+			partial:  []byte(strings.Repeat("x", 400)),
+			modified: downloadModified.Add(-time.Hour),
+			ranges:   []string{"bytes=400-"},
+		},
+		{
+			name:          "stale partial file, If-Range ignored",
+			ignoreIfRange: true,
+			partial:       []byte(strings.Repeat("x", 400)),
+			modified:      downloadModified.Add(-time.Hour),
+			ranges:        []string{"bytes=400-", ""},
+		},
+		{
+			name:     "complete partial file",
+			partial:  downloadContent,
+			modified: downloadModified,
+			ranges:   []string{"bytes=1000-"},
+		},
+		{
+			name:     "partial file larger than the file",
+			partial:  append(append([]byte{}, downloadContent...), "trailing"...),
+			modified: downloadModified,
+			ranges:   []string{"bytes=1008-", ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &downloadServer{ignoreIfRange: tt.ignoreIfRange}
+			url := server.start(t)
+			path := filepath.Join(t.TempDir(), "app.jar")
+
+			if tt.partial != nil {
+				writePartial(t, path, tt.partial, tt.modified)
+			}
+
+			if err := Download(context.Background(), path, url, DownloadOptions{Resume: true}); err != nil {
+				t.Fatal(err)
+			}
+
+			checkDownload(t, path)
+
+			if strings.Join(server.ranges, ",") != strings.Join(tt.ranges, ",") {
+				t.Errorf("Range headers = %q, want %q", server.ranges, tt.ranges)
+			}
+		})
+	}
+}
+
+func TestDownloadWithoutResume(t *testing.T) {
+	var server downloadServer
+	url := server.start(t)
+	path := filepath.Join(t.TempDir(), "app.jar")
+
+	writePartial(t, path, downloadContent[:400], downloadModified)
+
+	if err := Download(context.Background(), path, url, DownloadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	checkDownload(t, path)
+
+	if len(server.ranges) != 1 || server.ranges[0] != "" {
+		t.Errorf("Range headers = %q, want none", server.ranges)
+	}
+}
+
+func TestIsCurrent(t *testing.T) {
+	var server downloadServer
+	url := server.start(t)
+	path := filepath.Join(t.TempDir(), "app.jar")
+
+	if current, err := IsCurrent(context.Background(), path, url, DownloadOptions{}); err != nil || current {
+		t.Errorf("IsCurrent() of missing file = %v, %v", current, err)
+	}
+
+	if err := Download(context.Background(), path, url, DownloadOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if current, err := IsCurrent(context.Background(), path, url, DownloadOptions{}); err != nil || !current {
+		t.Errorf("IsCurrent() of downloaded file = %v, %v", current, err)
+	}
+
+	// A file of the same size from another build is not current
+	server.modified = downloadModified.Add(time.Hour)
+
+	if current, err := IsCurrent(context.Background(), path, url, DownloadOptions{}); err != nil || current {
+		t.Errorf("IsCurrent() of changed file = %v, %v", current, err)
+	}
+}
@@ -0,0 +1,73 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// Exit codes of Jenkins CLI commands, see hudson.cli.CLICommand
+const (
+	ExitUnknownError        = 1
+	ExitBadArguments        = 2
+	ExitIllegalArgument     = 3
+	ExitIllegalState        = 4
+	ExitAborted             = 5
+	ExitAccessDenied        = 6
+	ExitBadCredentials      = 7
+	ExitCustomCommandStatus = 16
+)
+
+// CliError is returned when a CLI command exits with a non-zero status
+type CliError struct {
+	Command  string
+	ExitCode int
+}
+
+func (e *CliError) Error() string {
+	switch {
+	case e.IsAuthFailure():
+		return fmt.Sprintf("command '%s' failed: access denied (exit status %d)", e.Command, e.ExitCode)
+	default:
+		return fmt.Sprintf("command '%s' exited with status %d", e.Command, e.ExitCode)
+	}
+}
+
+// IsAuthFailure reports whether the command failed due to missing
+// permissions or invalid credentials.
+func (e *CliError) IsAuthFailure() bool {
+	return e.ExitCode == ExitAccessDenied || e.ExitCode == ExitBadCredentials
+}
+
+// Kind classifies the failure as an auth failure or a rejected command
+func (e *CliError) Kind() errs.Kind {
+	if e.IsAuthFailure() {
+		return errs.KindAuth
+	}
+
+	return errs.KindValidation
+}
+
+// HTTPError is returned when Jenkins responds with a non-2xx status
+type HTTPError struct {
+	Path       string
+	StatusCode int
+	Status     string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("request to %s failed with status: %s", e.Path, e.Status)
+}
+
+// Kind classifies the failure by its status code
+func (e *HTTPError) Kind() errs.Kind {
+	switch e.StatusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return errs.KindAuth
+	case http.StatusNotFound:
+		return errs.KindNotFound
+	default:
+		return errs.KindUnknown
+	}
+}
@@ -0,0 +1,75 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// ResultRunning is reported for builds which have not finished yet
+const ResultRunning = "RUNNING"
+
+type BuildCause struct {
+	ShortDescription string `json:"shortDescription"`
+	UserId           string `json:"userId"`
+	UserName         string `json:"userName"`
+}
+
+type BuildSummary struct {
+	Number    int64  `json:"number"`
+	Result    string `json:"result"`
+	Building  bool   `json:"building"`
+	Duration  int64  `json:"duration"`
+	Timestamp int64  `json:"timestamp"`
+	Actions   []struct {
+		Causes []BuildCause `json:"causes"`
+	} `json:"actions"`
+}
+
+// ListBuilds returns the builds of a job from index start (inclusive) to
+// end (exclusive), newest first.
+func (c *Client) ListBuilds(ctx context.Context, job string, start int, end int) ([]BuildSummary, error) {
+	var resp struct {
+		Builds []BuildSummary `json:"builds"`
+	}
+
+	tree := fmt.Sprintf("builds[number,result,building,duration,timestamp,actions[causes[shortDescription,userId,userName]]]{%d,%d}", start, end)
+	err := c.getJSON(ctx, JobUrlPath(job)+"/api/json", url.Values{"tree": {tree}}, &resp)
+
+	return resp.Builds, err
+}
+
+// Status returns the result of the build, or ResultRunning if it has not finished
+func (b BuildSummary) Status() string {
+	if b.Building || b.Result == "" {
+		return ResultRunning
+	}
+
+	return b.Result
+}
+
+// StartTime returns when the build started
+func (b BuildSummary) StartTime() time.Time {
+	return time.UnixMilli(b.Timestamp)
+}
+
+// Elapsed returns the duration of the build, or the time it has been
+// running so far
+func (b BuildSummary) Elapsed(now time.Time) time.Duration {
+	if b.Building {
+		return now.Sub(b.StartTime())
+	}
+
+	return time.Duration(b.Duration) * time.Millisecond
+}
+
+// Causes returns the causes of the build
+func (b BuildSummary) Causes() []BuildCause {
+	var causes []BuildCause
+	for _, a := range b.Actions {
+		causes = append(causes, a.Causes...)
+	}
+
+	return causes
+}
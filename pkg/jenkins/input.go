@@ -0,0 +1,156 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// PendingInput is an input step waiting for a decision
+type PendingInput struct {
+	Id          string           `json:"id"`
+	Message     string           `json:"message"`
+	ProceedText string           `json:"proceedText"`
+	Inputs      []InputParameter `json:"inputs"`
+
+	// Submitter is the comma-separated list of users and groups allowed to
+	// submit the input, empty if anyone may
+	Submitter string `json:"submitter"`
+}
+
+// InputParameter is a parameter requested by an input step
+type InputParameter struct {
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Definition  map[string]interface{} `json:"definition"`
+}
+
+// ParameterDefinition converts the input parameter into a build parameter
+// definition, so it can be validated and prompted for the same way.
+func (p InputParameter) ParameterDefinition() ParameterDefinition {
+	d := ParameterDefinition{Name: p.Name, Type: p.Type, Description: p.Description}
+
+	if choices, ok := p.Definition["choices"].([]interface{}); ok {
+		for _, c := range choices {
+			d.Choices = append(d.Choices, fmt.Sprint(c))
+		}
+	}
+
+	for _, key := range []string{"defaultVal", "defaultValue"} {
+		if v, ok := p.Definition[key]; ok && v != nil {
+			d.DefaultParameterValue = &struct {
+				Value interface{} `json:"value"`
+			}{v}
+			break
+		}
+	}
+
+	return d
+}
+
+// GetPendingInputs returns the input steps a build is waiting on
+func (c *Client) GetPendingInputs(ctx context.Context, job string, build string) ([]PendingInput, error) {
+	var inputs []PendingInput
+
+	buildPath := fmt.Sprintf("%s/%s", JobUrlPath(job), BuildRef(build))
+	if err := c.getJSON(ctx, buildPath+"/wfapi/pendingInputActions", nil, &inputs); err != nil {
+		return nil, err
+	}
+
+	if len(inputs) == 0 {
+		return inputs, nil
+	}
+
+	// The workflow API does not report submitters, but the input action does
+	var resp struct {
+		Actions []struct {
+			Executions []struct {
+				Id    string `json:"id"`
+				Input struct {
+					Submitter string `json:"submitter"`
+				} `json:"input"`
+			} `json:"executions"`
+		} `json:"actions"`
+	}
+
+	query := url.Values{"tree": {"actions[executions[id,input[submitter]]]"}}
+	if err := c.getJSON(ctx, buildPath+"/api/json", query, &resp); err == nil {
+		for _, a := range resp.Actions {
+			for _, e := range a.Executions {
+				for i := range inputs {
+					if strings.EqualFold(inputs[i].Id, e.Id) {
+						inputs[i].Submitter = e.Input.Submitter
+					}
+				}
+			}
+		}
+	}
+
+	return inputs, nil
+}
+
+// ProceedInput submits an input step with the given parameter values
+func (c *Client) ProceedInput(ctx context.Context, job string, build string, input PendingInput, params map[string]string) error {
+	type parameter struct {
+		Name  string      `json:"name"`
+		Value interface{} `json:"value"`
+	}
+
+	var submitted struct {
+		Parameter []parameter `json:"parameter"`
+	}
+
+	for _, p := range input.Inputs {
+		d := p.ParameterDefinition()
+
+		value, ok := params[p.Name]
+		if !ok {
+			value = d.Default()
+		}
+
+		switch d.Kind() {
+		case ParamFile:
+			return errs.New(errs.KindValidation, "File parameter '%s' of input %s is not supported", p.Name, input.Id)
+		case ParamBoolean:
+			submitted.Parameter = append(submitted.Parameter, parameter{p.Name, value == "true"})
+		default:
+			submitted.Parameter = append(submitted.Parameter, parameter{p.Name, value})
+		}
+	}
+
+	j, err := json.Marshal(submitted)
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{"json": {string(j)}, "proceed": {input.ProceedText}}
+
+	return c.submitInput(ctx, job, build, input.Id, "proceed", form)
+}
+
+// AbortInput aborts the build waiting on an input step
+func (c *Client) AbortInput(ctx context.Context, job string, build string, input PendingInput) error {
+	return c.submitInput(ctx, job, build, input.Id, "abort", url.Values{})
+}
+
+func (c *Client) submitInput(ctx context.Context, job string, build string, id string, action string, form url.Values) error {
+	endpoint := fmt.Sprintf("%s/%s/input/%s/%s", JobUrlPath(job), BuildRef(build), url.PathEscape(id), action)
+
+	req, err := c.newPostRequest(ctx, endpoint, nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to %s input %s: %w", action, id, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
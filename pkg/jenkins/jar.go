@@ -0,0 +1,357 @@
+package jenkins
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+const (
+	cliJarName      = "cli.jar"
+	cliVersionFile  = "version"
+	checksumSuffix  = ".sha256"
+	unknownVersion  = "unknown"
+	manifestVersion = "Jenkins-CLI-Version:"
+)
+
+// GetJarsDir returns the directory of the CLI jars shared by all contexts,
+// which holds one subdirectory per CLI version.
+func GetJarsDir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(configDir, "cli", "jars"), nil
+}
+
+// GetCliPath returns the path of the CLI jar used for the context's host.
+// The host directory records the version of the shared jar to use; jars
+// downloaded before versions were shared live in the host directory itself.
+func (c JenkinsCli) GetCliPath() (string, error) {
+	dir, err := c.GetCliDir()
+	if err != nil {
+		return "", err
+	}
+
+	version, err := os.ReadFile(filepath.Join(dir, cliVersionFile))
+	if os.IsNotExist(err) {
+		return filepath.Join(dir, cliJarName), nil
+	} else if err != nil {
+		return "", err
+	}
+
+	jarsDir, err := GetJarsDir()
+	if err != nil {
+		return "", err
+	}
+
+	versionDir := strings.TrimSpace(string(version))
+	if !isVersionDir(versionDir) {
+		return "", errs.New(errs.KindConfig, "Invalid CLI version '%s' in %s. Please run 'jenkinsw cli update'.", versionDir, filepath.Join(dir, cliVersionFile))
+	}
+
+	return filepath.Join(jarsDir, versionDir, cliJarName), nil
+}
+
+var versionDirPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// isVersionDir reports whether the version can name a directory of the
+// shared jars directory
+func isVersionDir(version string) bool {
+	return versionDirPattern.MatchString(version) && version != "." && version != ".."
+}
+
+// DownloadCliJar downloads the CLI jar from the Jenkins host into the shared
+// jars directory, records its checksum, and selects it for the host. The jar
+// is written to a temporary file first so a failed download never replaces
+// a working jar.
+func (c JenkinsCli) DownloadCliJar(ctx context.Context) error {
+	jenkinsJarUrl := fmt.Sprintf("%s/jnlpJars/jenkins-cli.jar", strings.TrimSuffix(c.ctx.Host, "/"))
+
+	jarsDir, err := GetJarsDir()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(jarsDir, 0700); err != nil {
+		return err
+	}
+
+	token, err := c.ctx.Token()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(jarsDir, cliJarName+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmp.Close()
+	defer os.Remove(tmp.Name())
+
+	fmt.Fprintf(c.ioStreams.ErrOut, "Downloading Jenkins CLI from %s\n", jenkinsJarUrl)
+
+	opts := DownloadOptions{Username: c.ctx.Username, Password: token}
+	if err := Download(ctx, tmp.Name(), jenkinsJarUrl, opts); err != nil {
+		return err
+	}
+
+	version, err := readJarVersion(tmp.Name())
+	if err != nil {
+		return fmt.Errorf("downloaded file is not a valid CLI jar: %s", err)
+	}
+
+	checksum, err := fileChecksum(tmp.Name())
+	if err != nil {
+		return err
+	}
+
+	// Jars without a usable version are shared by checksum instead
+	if version == unknownVersion || !isVersionDir(version) {
+		version = "sha256-" + checksum[:12]
+	}
+
+	versionDir := filepath.Join(jarsDir, version)
+	path := filepath.Join(versionDir, cliJarName)
+
+	if isVerified(path, checksum) {
+		log.Debug("Reusing shared CLI jar ", path)
+	} else {
+		if err := os.MkdirAll(versionDir, 0700); err != nil {
+			return err
+		}
+
+		if err := os.Rename(tmp.Name(), path); err != nil {
+			return err
+		}
+
+		if err := writeFileAtomic(path+checksumSuffix, []byte(fmt.Sprintf("%s  %s\n", checksum, cliJarName))); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(c.ioStreams.ErrOut, "Using Jenkins CLI %s at %s\n", version, path)
+
+	dir, err := c.GetCliDir()
+	if err != nil {
+		return err
+	}
+
+	if err = os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	if err := writeFileAtomic(filepath.Join(dir, cliVersionFile), []byte(version+"\n")); err != nil {
+		return err
+	}
+
+	// Remove the jar from before versions were shared
+	if err := os.Remove(filepath.Join(dir, cliJarName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Verify checks the CLI jar against its recorded SHA-256 checksum
+func (c JenkinsCli) Verify() error {
+	path, err := c.GetCliPath()
+	if err != nil {
+		return err
+	}
+
+	recorded, err := readChecksum(path)
+	if os.IsNotExist(err) {
+		return errs.New(errs.KindConfig, "No checksum recorded for %s. Please run 'jenkinsw cli update --force'.", path)
+	} else if err != nil {
+		return err
+	}
+
+	actual, err := fileChecksum(path)
+	if err != nil {
+		return err
+	}
+
+	if actual != recorded {
+		return errs.New(errs.KindValidation, "Checksum mismatch for %s: expected %s, got %s", path, recorded, actual)
+	}
+
+	return nil
+}
+
+// Refresh downloads the CLI jar if it is missing or its version differs
+// from the server's. The present jar is kept if the server version cannot
+// be read, or if the jar does not declare its version.
+func (c JenkinsCli) Refresh(ctx context.Context) error {
+	version, err := c.Version()
+	if err != nil {
+		log.Debug("CLI jar not usable: ", err)
+		return c.DownloadCliJar(ctx)
+	}
+
+	if version == unknownVersion {
+		return nil
+	}
+
+	serverVersion, err := c.ServerVersion(ctx)
+	if err != nil {
+		log.Debug("Unable to read the server version: ", err)
+		return nil
+	}
+
+	if version != serverVersion {
+		log.Debug("CLI jar ", version, " does not match server version ", serverVersion)
+		return c.DownloadCliJar(ctx)
+	}
+
+	return nil
+}
+
+func (c JenkinsCli) Version() (version string, err error) {
+	path, err := c.GetCliPath()
+	if err != nil {
+		return "", err
+	}
+
+	return readJarVersion(path)
+}
+
+// ServerVersion returns the Jenkins version from the X-Jenkins header
+func (c JenkinsCli) ServerVersion(ctx context.Context) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, "HEAD", strings.TrimSuffix(c.ctx.Host, "/")+"/login", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	version := resp.Header.Get("X-Jenkins")
+	if version == "" {
+		return "", fmt.Errorf("%s did not report a Jenkins version", c.ctx.Host)
+	}
+
+	return version, nil
+}
+
+// readJarVersion reads the Jenkins-CLI-Version from the manifest of a jar
+func readJarVersion(path string) (string, error) {
+	archive, err := zip.OpenReader(path)
+	if err != nil {
+		return "", err
+	}
+	defer archive.Close()
+
+	for _, f := range archive.File {
+		if f.Name != "META-INF/MANIFEST.MF" {
+			continue
+		}
+
+		manifestFile, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		defer manifestFile.Close()
+
+		version := unknownVersion
+		scanner := bufio.NewScanner(manifestFile)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, manifestVersion) {
+				words := strings.Fields(line)
+				if len(words) == 2 {
+					version = words[1]
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return "", err
+		}
+
+		return version, nil
+	}
+
+	return unknownVersion, nil
+}
+
+func fileChecksum(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// isVerified reports whether the file and its recorded checksum both match
+func isVerified(path string, checksum string) bool {
+	recorded, err := readChecksum(path)
+	if err != nil || recorded != checksum {
+		return false
+	}
+
+	actual, err := fileChecksum(path)
+
+	return err == nil && actual == checksum
+}
+
+// readChecksum reads the checksum recorded alongside a file
+func readChecksum(path string) (string, error) {
+	b, err := os.ReadFile(path + checksumSuffix)
+	if err != nil {
+		return "", err
+	}
+
+	fields := strings.Fields(string(b))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("empty checksum file %s", path+checksumSuffix)
+	}
+
+	return fields[0], nil
+}
+
+// writeFileAtomic writes a file through a temporary file and rename, so
+// readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,134 @@
+package jenkins
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+func TestIsVersionDir(t *testing.T) {
+	tests := map[string]bool{
+		"2.426.1":          true,
+		"2.440-SNAPSHOT":   true,
+		"sha256-0123abcd":  true,
+		"":                 false,
+		".":                false,
+		"..":               false,
+		"../../etc":        false,
+		`2.4\..\x`:         false,
+		"2.426 (custom)":   false,
+		"2.426\x00":        false,
+		"2.426/../../evil": false,
+	}
+
+	for version, want := range tests {
+		if got := isVersionDir(version); got != want {
+			t.Errorf("isVersionDir(%q) = %v, want %v", version, got, want)
+		}
+	}
+}
+
+// cliJar returns a jar declaring the CLI version in its manifest
+func cliJar(t *testing.T, version string) []byte {
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+
+	f, err := w.Create("META-INF/MANIFEST.MF")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Write([]byte("Manifest-Version: 1.0\r\n" + manifestVersion + " " + version + "\r\n")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestRefresh(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	serverVersion := "2.426.1"
+	downloads := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Jenkins", serverVersion)
+		if r.URL.Path == "/jnlpJars/jenkins-cli.jar" {
+			downloads++
+			w.Write(cliJar(t, serverVersion))
+		}
+	}))
+	defer srv.Close()
+
+	streams := utils.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	cli := NewJenkinsCli(&config.Context{Name: "test", Host: srv.URL, Username: "user", ApiToken: "token"}, &streams)
+
+	refresh := func(wantVersion string, wantDownloads int) {
+		t.Helper()
+
+		if err := cli.Refresh(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+
+		if version, err := cli.Version(); err != nil || version != wantVersion {
+			t.Errorf("Version() = %q, %v, want %q", version, err, wantVersion)
+		}
+
+		if downloads != wantDownloads {
+			t.Errorf("downloads = %d, want %d", downloads, wantDownloads)
+		}
+	}
+
+	// A missing jar is downloaded, a current one is kept
+	refresh("2.426.1", 1)
+	refresh("2.426.1", 1)
+
+	// A server upgrade replaces the jar
+	serverVersion = "2.440.3"
+	refresh("2.440.3", 2)
+
+	if err := cli.Verify(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyChecksumMismatch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(cliJar(t, "2.426.1"))
+	}))
+	defer srv.Close()
+
+	streams := utils.IOStreams{Out: &bytes.Buffer{}, ErrOut: &bytes.Buffer{}}
+	cli := NewJenkinsCli(&config.Context{Name: "test", Host: srv.URL, Username: "user", ApiToken: "token"}, &streams)
+
+	if err := cli.DownloadCliJar(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := cli.GetCliPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(path, cliJar(t, "tampered"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = cli.Verify()
+	if kind := errs.KindOf(err); kind != errs.KindValidation {
+		t.Errorf("Verify() = %v (kind %v), want a validation error", err, kind)
+	}
+}
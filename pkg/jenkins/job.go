@@ -0,0 +1,97 @@
+package jenkins
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	FolderClass             = "com.cloudbees.hudson.plugins.folder.Folder"
+	OrganizationFolderClass = "jenkins.branch.OrganizationFolder"
+	MultiBranchProjectClass = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+)
+
+// IsFolder reports whether jobs of the given class contain other jobs
+func IsFolder(class string) bool {
+	return class == FolderClass || class == OrganizationFolderClass
+}
+
+// FullName converts a job name as returned by Client.ListJobs, like
+// "folder/job/project", into its full name "folder/project".
+func FullName(name string) string {
+	return strings.ReplaceAll(name, "/job/", "/")
+}
+
+// BranchJobName returns the full name of a branch job within a multibranch
+// project. Multibranch projects encode slashes in branch names as %2F.
+func BranchJobName(project string, branch string) string {
+	return strings.Trim(project, "/") + "/" + url.PathEscape(branch)
+}
+
+// JobUrlPath converts a full job name like "folder/project/main" into its
+// URL path "/job/folder/job/project/job/main".
+func JobUrlPath(fullName string) string {
+	var b strings.Builder
+
+	for _, name := range strings.Split(strings.Trim(fullName, "/"), "/") {
+		b.WriteString("/job/")
+		b.WriteString(url.PathEscape(name))
+	}
+
+	return b.String()
+}
+
+// BuildRef returns the build number or permalink used in build URLs,
+// defaulting to the last build.
+func BuildRef(build string) string {
+	if build == "" {
+		return "lastBuild"
+	}
+
+	return build
+}
+
+// JobUrl returns the URL of a job on the Jenkins host
+func JobUrl(host string, fullName string) string {
+	return strings.TrimSuffix(host, "/") + JobUrlPath(fullName) + "/"
+}
+
+// BuildUrl returns the URL of a build, defaulting to the last build
+func BuildUrl(host string, fullName string, build string) string {
+	return JobUrl(host, fullName) + BuildRef(build) + "/"
+}
+
+// BlueOceanUrl returns the Blue Ocean URL of a branch of a multibranch
+// project, showing the given build or the branch activity if build is empty.
+func BlueOceanUrl(host string, project string, branch string, build string) string {
+	base := fmt.Sprintf("%s/blue/organizations/jenkins/%s", strings.TrimSuffix(host, "/"), url.PathEscape(strings.Trim(project, "/")))
+
+	if build == "" {
+		return fmt.Sprintf("%s/activity?branch=%s", base, url.QueryEscape(branch))
+	}
+
+	return fmt.Sprintf("%s/detail/%s/%s/pipeline", base, url.PathEscape(branch), url.PathEscape(build))
+}
+
+// JobNameFromUrl returns the full name of the job at a URL like
+// "https://jenkins.example.com/job/folder/job/project/", or an empty string
+// if it is not a job URL.
+func JobNameFromUrl(jobUrl string) string {
+	u, err := url.Parse(jobUrl)
+	if err != nil {
+		return ""
+	}
+
+	var names []string
+
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] == "job" {
+			names = append(names, segments[i+1])
+			i++
+		}
+	}
+
+	return strings.Join(names, "/")
+}
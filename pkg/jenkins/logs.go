@@ -0,0 +1,148 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+type LogOptions struct {
+	Follow       bool
+	Tail         int
+	SinceStage   string
+	Stage        string
+	PollInterval time.Duration
+}
+
+// ProgressiveText fetches console output of a build starting at the given
+// byte offset. It returns the text, the offset to continue from, and whether
+// more output is expected.
+func (c *Client) ProgressiveText(ctx context.Context, job string, build string, start int64) (text string, next int64, more bool, err error) {
+	path := fmt.Sprintf("%s/%s/logText/progressiveText", JobUrlPath(job), BuildRef(build))
+	query := url.Values{"start": {strconv.FormatInt(start, 10)}}
+
+	req, err := c.newRequest(ctx, "GET", path, query, nil)
+	if err != nil {
+		return "", start, false, err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", start, false, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", start, false, err
+	}
+
+	next = start + int64(len(body))
+	if size := resp.Header.Get("X-Text-Size"); size != "" {
+		if next, err = strconv.ParseInt(size, 10, 64); err != nil {
+			return "", start, false, err
+		}
+	}
+
+	more = resp.Header.Get("X-More-Data") == "true"
+
+	return string(body), next, more, nil
+}
+
+// StreamConsole writes the console output of a build to w, optionally
+// following it until the build finishes.
+func (c *Client) StreamConsole(ctx context.Context, job string, build string, w io.Writer, opts LogOptions) error {
+	if opts.PollInterval == 0 {
+		opts.PollInterval = time.Second
+	}
+
+	if opts.Stage != "" {
+		if opts.Follow || opts.SinceStage != "" {
+			return errs.New(errs.KindValidation, "The stage option cannot be combined with follow or since-stage")
+		}
+
+		return c.stageLog(ctx, job, build, w, opts)
+	}
+
+	text, next, more, err := c.ProgressiveText(ctx, job, build, 0)
+	if err != nil {
+		return err
+	}
+
+	// Keep the raw output while the stage is pending, since its marker may
+	// be split across chunks
+	buffered := text
+
+	pending := opts.SinceStage != ""
+	if pending {
+		if text, pending = sinceStage(buffered, opts.SinceStage); pending && !(opts.Follow && more) {
+			return errs.New(errs.KindNotFound, "Stage '%s' not found in console output", opts.SinceStage)
+		}
+	}
+
+	if opts.Tail > 0 {
+		text = tail(text, opts.Tail)
+	}
+
+	if !pending {
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+	}
+
+	for opts.Follow && more {
+		if err := sleep(ctx, opts.PollInterval); err != nil {
+			return err
+		}
+
+		if text, next, more, err = c.ProgressiveText(ctx, job, build, next); err != nil {
+			return err
+		}
+
+		if pending {
+			buffered += text
+			if text, pending = sinceStage(buffered, opts.SinceStage); pending {
+				continue
+			}
+			buffered = ""
+		}
+
+		if _, err := io.WriteString(w, text); err != nil {
+			return err
+		}
+	}
+
+	if pending {
+		return errs.New(errs.KindNotFound, "Stage '%s' not found in console output", opts.SinceStage)
+	}
+
+	return nil
+}
+
+// sinceStage returns the text starting at the line where the named stage
+// begins, and whether the stage has not been found yet.
+func sinceStage(text string, stage string) (string, bool) {
+	i := strings.Index(text, fmt.Sprintf("[Pipeline] { (%s)", stage))
+	if i < 0 {
+		return "", true
+	}
+
+	return text[strings.LastIndex(text[:i], "\n")+1:], false
+}
+
+// tail returns the last n lines of text
+func tail(text string, n int) string {
+	trimmed := strings.TrimSuffix(text, "\n")
+	lines := strings.SplitAfter(trimmed, "\n")
+	if len(lines) <= n {
+		return text
+	}
+
+	return strings.Join(lines[len(lines)-n:], "") + text[len(trimmed):]
+}
@@ -0,0 +1,85 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSinceStage(t *testing.T) {
+	log := "Started by user\n[Pipeline] { (Build)\nbuilding\n[Pipeline] { (Test)\ntesting\n"
+
+	tests := []struct {
+		stage   string
+		want    string
+		pending bool
+	}{
+		{"Build", "[Pipeline] { (Build)\nbuilding\n[Pipeline] { (Test)\ntesting\n", false},
+		{"Test", "[Pipeline] { (Test)\ntesting\n", false},
+		{"Deploy", "", true},
+	}
+
+	for _, tt := range tests {
+		got, pending := sinceStage(log, tt.stage)
+		if got != tt.want || pending != tt.pending {
+			t.Errorf("sinceStage(%q) = %q, %v, want %q, %v", tt.stage, got, pending, tt.want, tt.pending)
+		}
+	}
+}
+
+func TestTail(t *testing.T) {
+	tests := []struct {
+		text string
+		n    int
+		want string
+	}{
+		{"a\nb\nc\n", 2, "b\nc\n"},
+		{"a\nb\nc", 2, "b\nc"},
+		{"a\nb\n", 5, "a\nb\n"},
+		{"a\nb\nc\n", 1, "c\n"},
+		{"", 3, ""},
+	}
+
+	for _, tt := range tests {
+		if got := tail(tt.text, tt.n); got != tt.want {
+			t.Errorf("tail(%q, %d) = %q, want %q", tt.text, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestStreamConsoleSinceStageSplitMarker follows a log whose stage marker is
+// split across two chunks of progressive output.
+func TestStreamConsoleSinceStageSplitMarker(t *testing.T) {
+	chunks := []string{"Started\n[Pipeline] { (Te", "st)\ntesting\n", "done\n"}
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+
+		offset := 0
+		for i, chunk := range chunks {
+			if offset == start {
+				w.Header().Set("X-Text-Size", strconv.Itoa(start+len(chunk)))
+				if i < len(chunks)-1 {
+					w.Header().Set("X-More-Data", "true")
+				}
+				w.Write([]byte(chunk))
+				return
+			}
+			offset += len(chunk)
+		}
+		http.Error(w, "bad offset", http.StatusBadRequest)
+	}))
+
+	var out strings.Builder
+	opts := LogOptions{Follow: true, SinceStage: "Test", PollInterval: time.Millisecond}
+	if err := c.StreamConsole(context.Background(), "app", "1", &out, opts); err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "[Pipeline] { (Test)\ntesting\ndone\n"; out.String() != want {
+		t.Errorf("StreamConsole() wrote %q, want %q", out.String(), want)
+	}
+}
@@ -0,0 +1,162 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// Kinds of build parameters
+const (
+	ParamString   = "string"
+	ParamText     = "text"
+	ParamBoolean  = "boolean"
+	ParamChoice   = "choice"
+	ParamPassword = "password"
+	ParamFile     = "file"
+)
+
+type ParameterDefinition struct {
+	Name                  string   `json:"name"`
+	Type                  string   `json:"type"`
+	Description           string   `json:"description"`
+	Choices               []string `json:"choices"`
+	DefaultParameterValue *struct {
+		Value interface{} `json:"value"`
+	} `json:"defaultParameterValue"`
+}
+
+// Kind returns the kind of the parameter from its definition type,
+// e.g. ParamChoice for ChoiceParameterDefinition.
+func (d ParameterDefinition) Kind() string {
+	t := strings.ToLower(d.Type)
+
+	switch {
+	case strings.Contains(t, "boolean"):
+		return ParamBoolean
+	case strings.Contains(t, "choice"):
+		return ParamChoice
+	case strings.Contains(t, "password"):
+		return ParamPassword
+	case strings.Contains(t, "file"):
+		return ParamFile
+	case strings.Contains(t, "text"):
+		return ParamText
+	default:
+		return ParamString
+	}
+}
+
+// Default returns the default value of the parameter, if any. Like in
+// Jenkins, choice parameters default to their first choice.
+func (d ParameterDefinition) Default() string {
+	if d.DefaultParameterValue == nil || d.DefaultParameterValue.Value == nil {
+		if d.Kind() == ParamChoice && len(d.Choices) > 0 {
+			return d.Choices[0]
+		}
+		return ""
+	}
+
+	return fmt.Sprint(d.DefaultParameterValue.Value)
+}
+
+// Validate checks a value against the parameter type. Values of file
+// parameters are paths of local files to upload.
+func (d ParameterDefinition) Validate(value string) error {
+	switch d.Kind() {
+	case ParamBoolean:
+		if value != "true" && value != "false" {
+			return errs.New(errs.KindValidation, "Parameter '%s' must be true or false, got '%s'", d.Name, value)
+		}
+	case ParamChoice:
+		for _, c := range d.Choices {
+			if c == value {
+				return nil
+			}
+		}
+		return errs.New(errs.KindValidation, "Parameter '%s' must be one of [%s], got '%s'", d.Name, strings.Join(d.Choices, ", "), value)
+	case ParamFile:
+		if _, err := os.Stat(value); err != nil {
+			return errs.New(errs.KindValidation, "File for parameter '%s' not found: %s", d.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// GetParameterDefinitions returns the parameters declared by the job's
+// ParametersDefinitionProperty.
+func (c *Client) GetParameterDefinitions(ctx context.Context, job string) ([]ParameterDefinition, error) {
+	var resp struct {
+		Property []struct {
+			ParameterDefinitions []ParameterDefinition `json:"parameterDefinitions"`
+		} `json:"property"`
+	}
+
+	query := url.Values{"tree": {"property[parameterDefinitions[name,type,description,choices,defaultParameterValue[value]]]"}}
+	if err := c.getJSON(ctx, JobUrlPath(job)+"/api/json", query, &resp); err != nil {
+		return nil, err
+	}
+
+	var defs []ParameterDefinition
+	for _, p := range resp.Property {
+		defs = append(defs, p.ParameterDefinitions...)
+	}
+
+	return defs, nil
+}
+
+// ValidateParameters checks the values against the job's parameter definitions
+func ValidateParameters(defs []ParameterDefinition, params map[string]string) error {
+	for name, value := range params {
+		found := false
+		for _, d := range defs {
+			if d.Name == name {
+				if err := d.Validate(value); err != nil {
+					return err
+				}
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return errs.New(errs.KindValidation, "Unknown parameter '%s'", name)
+		}
+	}
+
+	return nil
+}
+
+// GetBuildParameters returns the parameters a build was started with.
+// Values Jenkins does not disclose, such as passwords, are omitted.
+func (c *Client) GetBuildParameters(ctx context.Context, job string, build string) (map[string]string, error) {
+	var resp struct {
+		Actions []struct {
+			Parameters []struct {
+				Name  string      `json:"name"`
+				Value interface{} `json:"value"`
+			} `json:"parameters"`
+		} `json:"actions"`
+	}
+
+	query := url.Values{"tree": {"actions[parameters[name,value]]"}}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/%s/api/json", JobUrlPath(job), BuildRef(build)), query, &resp); err != nil {
+		return nil, err
+	}
+
+	params := map[string]string{}
+	for _, a := range resp.Actions {
+		for _, p := range a.Parameters {
+			if p.Value != nil {
+				params[p.Name] = fmt.Sprint(p.Value)
+			}
+		}
+	}
+
+	return params, nil
+}
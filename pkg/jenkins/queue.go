@@ -0,0 +1,78 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Actions used to stop a running build, in order of escalation
+const (
+	StopAction = "stop"
+	TermAction = "term"
+	KillAction = "kill"
+)
+
+// StopActions are the actions tried in turn to stop a build
+var StopActions = []string{StopAction, TermAction, KillAction}
+
+// ListQueue returns the items waiting in the build queue
+func (c *Client) ListQueue(ctx context.Context) ([]QueueItem, error) {
+	var resp struct {
+		Items []QueueItem `json:"items"`
+	}
+
+	query := url.Values{"tree": {"items[id,why,cancelled,inQueueSince,task[name,url]]"}}
+	err := c.getJSON(ctx, "/queue/api/json", query, &resp)
+
+	return resp.Items, err
+}
+
+// JobName returns the full name of the queued job
+func (i QueueItem) JobName() string {
+	return JobNameFromUrl(i.Task.Url)
+}
+
+// Waiting returns how long the item has been in the queue
+func (i QueueItem) Waiting(now time.Time) time.Duration {
+	if i.InQueueSince == 0 {
+		return 0
+	}
+
+	return now.Sub(time.UnixMilli(i.InQueueSince))
+}
+
+// CancelQueueItem removes an item from the build queue
+func (c *Client) CancelQueueItem(ctx context.Context, id int64) error {
+	req, err := c.newPostRequest(ctx, "/queue/cancelItem", url.Values{"id": {strconv.FormatInt(id, 10)}}, "", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to cancel queue item %d: %w", id, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// StopBuild sends a stop action to a running build. The "term" and "kill"
+// actions forcibly stop pipelines that do not respond to "stop".
+func (c *Client) StopBuild(ctx context.Context, job string, build string, action string) error {
+	req, err := c.newPostRequest(ctx, fmt.Sprintf("%s/%s/%s", JobUrlPath(job), BuildRef(build), action), nil, "", nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// RestartActionClass is the class of the action restarting declarative
+// pipelines from a stage
+const RestartActionClass = "org.jenkinsci.plugins.pipeline.modeldefinition.actions.RestartDeclarativePipelineAction"
+
+// RestartCauseClass is the cause of builds restarted from a stage
+const RestartCauseClass = "org.jenkinsci.plugins.pipeline.modeldefinition.causes.RestartDeclarativePipelineCause"
+
+// GetRestartableStages returns the stages a completed declarative pipeline
+// build can be restarted from. Servers which do not export the restartable
+// stages report the top-level stages of the build instead.
+func (c *Client) GetRestartableStages(ctx context.Context, job string, build string) ([]string, error) {
+	var resp struct {
+		Actions []struct {
+			Class             string   `json:"_class"`
+			RestartEnabled    *bool    `json:"restartEnabled"`
+			RestartableStages []string `json:"restartableStages"`
+		} `json:"actions"`
+	}
+
+	query := url.Values{"tree": {"actions[_class,restartEnabled,restartableStages]"}}
+	if err := c.getJSON(ctx, fmt.Sprintf("%s/%s/api/json", JobUrlPath(job), BuildRef(build)), query, &resp); err != nil {
+		return nil, err
+	}
+
+	for _, a := range resp.Actions {
+		if a.Class != RestartActionClass {
+			continue
+		}
+
+		if a.RestartEnabled != nil && !*a.RestartEnabled {
+			return nil, errs.New(errs.KindValidation, "Restarting %s #%s from a stage is not enabled", job, BuildRef(build))
+		}
+
+		if a.RestartableStages != nil {
+			return a.RestartableStages, nil
+		}
+
+		run, err := c.DescribeRun(ctx, job, build)
+		if err != nil {
+			return nil, err
+		}
+
+		stages := make([]string, len(run.Stages))
+		for i, s := range run.Stages {
+			stages[i] = s.Name
+		}
+
+		return stages, nil
+	}
+
+	return nil, errs.New(errs.KindValidation, "%s #%s is not a declarative pipeline build that can be restarted from a stage", job, BuildRef(build))
+}
+
+// RestartFromStage restarts a completed declarative pipeline build from the
+// named stage, which starts a new build.
+func (c *Client) RestartFromStage(ctx context.Context, job string, build string, stage string) error {
+	j, err := json.Marshal(map[string]string{"stageName": stage})
+	if err != nil {
+		return err
+	}
+
+	form := url.Values{"stageName": {stage}, "json": {string(j)}}
+	endpoint := fmt.Sprintf("%s/%s/restart/restart", JobUrlPath(job), BuildRef(build))
+
+	req, err := c.newPostRequest(ctx, endpoint, nil, "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.do(req)
+	if err != nil {
+		return fmt.Errorf("Failed to restart %s #%s from stage '%s': %w", job, BuildRef(build), stage, err)
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// GetNextBuildNumber returns the number the next build of the job will get
+func (c *Client) GetNextBuildNumber(ctx context.Context, job string) (int64, error) {
+	var resp struct {
+		NextBuildNumber int64 `json:"nextBuildNumber"`
+	}
+
+	err := c.getJSON(ctx, JobUrlPath(job)+"/api/json", url.Values{"tree": {"nextBuildNumber"}}, &resp)
+
+	return resp.NextBuildNumber, err
+}
+
+// WaitForRestartedBuild waits until the build restarted from the stage of
+// the origin build starts and returns its number. The restarted build is
+// told apart from other builds of the job by its cause; builds numbered
+// below since are ignored.
+func (c *Client) WaitForRestartedBuild(ctx context.Context, job string, origin int64, stage string, since int64) (int64, error) {
+	for {
+		var resp struct {
+			Builds []struct {
+				Number  int64 `json:"number"`
+				Actions []struct {
+					Causes []struct {
+						Class           string `json:"_class"`
+						OriginRunNumber int64  `json:"originRunNumber"`
+						OriginStage     string `json:"originStage"`
+					} `json:"causes"`
+				} `json:"actions"`
+			} `json:"builds"`
+		}
+
+		query := url.Values{"tree": {"builds[number,actions[causes[originRunNumber,originStage]]]{0,20}"}}
+		if err := c.getJSON(ctx, JobUrlPath(job)+"/api/json", query, &resp); err != nil {
+			return 0, err
+		}
+
+		for _, b := range resp.Builds {
+			if b.Number < since {
+				continue
+			}
+
+			for _, a := range b.Actions {
+				for _, cause := range a.Causes {
+					if cause.Class == RestartCauseClass && cause.OriginRunNumber == origin && cause.OriginStage == stage {
+						return b.Number, nil
+					}
+				}
+			}
+		}
+
+		if err := sleep(ctx, PollInterval); err != nil {
+			return 0, err
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package jenkins
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestWaitForRestartedBuild(t *testing.T) {
+	// Build 14 was queued between reading the next build number and the
+	// restart, 15 restarted another stage and 16 is the restarted build
+	builds := `{"builds": [
+		{"number": 16, "actions": [{"causes": [{"_class": "` + RestartCauseClass + `", "originRunNumber": 10, "originStage": "Test"}]}]},
+		{"number": 15, "actions": [{"causes": [{"_class": "` + RestartCauseClass + `", "originRunNumber": 10, "originStage": "Deploy"}]}]},
+		{"number": 14, "actions": [{"causes": [{"_class": "hudson.model.Cause$UserIdCause"}]}, {}]},
+		{"number": 12, "actions": [{"causes": [{"_class": "` + RestartCauseClass + `", "originRunNumber": 10, "originStage": "Test"}]}]}
+	]}`
+
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/job/app/job/main/api/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(builds))
+	}))
+
+	number, err := c.WaitForRestartedBuild(context.Background(), "app/main", 10, "Test", 14)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if number != 16 {
+		t.Errorf("WaitForRestartedBuild() = %d, want 16", number)
+	}
+}
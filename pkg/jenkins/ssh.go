@@ -0,0 +1,225 @@
+package jenkins
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// sshTransport runs CLI commands through the built-in SSH server of Jenkins
+type sshTransport struct {
+	ctx *config.Context
+}
+
+func (t sshTransport) Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	endpoint, err := t.getEndpoint(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	auth, agentConn, err := t.getAuthMethod()
+	if err != nil {
+		return -1, err
+	}
+	if agentConn != nil {
+		defer agentConn.Close()
+	}
+
+	hostKeyCallback, err := t.getHostKeyCallback()
+	if err != nil {
+		return -1, err
+	}
+
+	log.Debug("Connecting to ", t.ctx.Username, "@", endpoint)
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", endpoint)
+	if err != nil {
+		return -1, fmt.Errorf("unable to connect to %s: %w", endpoint, err)
+	}
+
+	// Closing the connection aborts the handshake or command if ctx is done
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+
+	sshConn, chans, reqs, err := ssh.NewClientConn(conn, endpoint, &ssh.ClientConfig{
+		User:            t.ctx.Username,
+		Auth:            []ssh.AuthMethod{auth},
+		HostKeyCallback: hostKeyCallback,
+	})
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return -1, ctx.Err()
+		}
+		return -1, fmt.Errorf("unable to connect to %s: %w", endpoint, err)
+	}
+
+	client := ssh.NewClient(sshConn, chans, reqs)
+	defer client.Close()
+
+	session, err := client.NewSession()
+	if err != nil {
+		return -1, err
+	}
+	defer session.Close()
+
+	session.Stdin = stdin
+	session.Stdout = stdout
+	session.Stderr = stderr
+
+	err = session.Run(quoteArgs(args))
+	if ctx.Err() != nil {
+		return -1, ctx.Err()
+	}
+
+	var exitErr *ssh.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitStatus(), nil
+	}
+
+	if err != nil {
+		return -1, err
+	}
+
+	return 0, nil
+}
+
+// getEndpoint returns the configured SSH endpoint, or the one advertised by
+// Jenkins in the X-SSH-Endpoint header.
+func (t sshTransport) getEndpoint(ctx context.Context) (string, error) {
+	if t.ctx.SshEndpoint != "" {
+		return t.ctx.SshEndpoint, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "HEAD", strings.TrimSuffix(t.ctx.Host, "/")+"/login", nil)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+
+	endpoint := resp.Header.Get("X-SSH-Endpoint")
+	if endpoint == "" {
+		return "", errs.New(errs.KindConfig, "SSH server is not enabled on %s. Please enable it or set 'sshEndpoint' for context '%s'.", t.ctx.Host, t.ctx.Name)
+	}
+
+	return endpoint, nil
+}
+
+// getAuthMethod authenticates with the context's key file, or the SSH agent.
+// The connection to the agent, if any, must be closed after the command ran.
+func (t sshTransport) getAuthMethod() (ssh.AuthMethod, io.Closer, error) {
+	if t.ctx.SshKeyFile != "" {
+		key, err := os.ReadFile(t.ctx.SshKeyFile)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, nil, errs.New(errs.KindConfig, "unable to parse SSH key %s: %s", t.ctx.SshKeyFile, err)
+		}
+
+		return ssh.PublicKeys(signer), nil, nil
+	}
+
+	sock := os.Getenv("SSH_AUTH_SOCK")
+	if sock == "" {
+		return nil, nil, errs.New(errs.KindConfig, "No SSH agent running and no 'sshKeyFile' set for context '%s'", t.ctx.Name)
+	}
+
+	conn, err := net.Dial("unix", sock)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to connect to SSH agent: %s", err)
+	}
+
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers), conn, nil
+}
+
+// getHostKeyCallback verifies host keys against the context's known_hosts
+// file. Keys of unknown hosts are trusted on first use and recorded.
+func (t sshTransport) getHostKeyCallback() (ssh.HostKeyCallback, error) {
+	file, err := t.ctx.GetKnownHostsFile()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(file, os.O_CREATE|os.O_RDONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	f.Close()
+
+	callback, err := knownhosts.New(file)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		err := callback(hostname, remote, key)
+
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+
+		if len(keyErr.Want) > 0 {
+			return fmt.Errorf("host key of %s does not match the key recorded in %s", hostname, file)
+		}
+
+		log.Info("Adding host key for ", hostname, " to ", file)
+
+		f, err := os.OpenFile(file, os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		_, err = fmt.Fprintln(f, knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key))
+
+		return err
+	}, nil
+}
+
+// quoteArgs joins arguments into a command line for the Jenkins SSH server,
+// which splits it like hudson.util.QuotedStringTokenizer.
+func quoteArgs(args []string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = `"` + r.Replace(arg) + `"`
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,266 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+)
+
+// newSSHServer starts a stand-in for the Jenkins SSH server accepting the
+// user key. It echoes the command and its stdin and exits with exitCode.
+func newSSHServer(t *testing.T, hostKey ssh.Signer, userKey ssh.PublicKey, exitCode uint32) string {
+	t.Helper()
+
+	cfg := &ssh.ServerConfig{
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == "user" && bytes.Equal(key.Marshal(), userKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, fmt.Errorf("unknown key for %s", c.User())
+		},
+	}
+	cfg.AddHostKey(hostKey)
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go serveSSH(conn, cfg, exitCode)
+		}
+	}()
+
+	return l.Addr().String()
+}
+
+func serveSSH(conn net.Conn, cfg *ssh.ServerConfig, exitCode uint32) {
+	defer conn.Close()
+
+	_, chans, reqs, err := ssh.NewServerConn(conn, cfg)
+	if err != nil {
+		return
+	}
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "")
+			continue
+		}
+
+		ch, chReqs, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+
+		go func() {
+			defer ch.Close()
+
+			for req := range chReqs {
+				if req.Type != "exec" {
+					req.Reply(false, nil)
+					continue
+				}
+
+				var exec struct{ Command string }
+				ssh.Unmarshal(req.Payload, &exec)
+				req.Reply(true, nil)
+
+				stdin, _ := io.ReadAll(ch)
+				fmt.Fprintf(ch, "%s < %s", exec.Command, stdin)
+				ch.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{exitCode}))
+				return
+			}
+		}()
+	}
+}
+
+func newSSHKey(t *testing.T) (*ecdsa.PrivateKey, ssh.Signer) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return key, signer
+}
+
+func writeSSHKeyFile(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(t.TempDir(), "id_ecdsa")
+	if err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return file
+}
+
+func runSSH(ctx *config.Context, args []string, stdin string) (int, string, error) {
+	var stdout bytes.Buffer
+	code, err := sshTransport{ctx: ctx}.Run(context.Background(), args, strings.NewReader(stdin), &stdout, io.Discard)
+
+	return code, stdout.String(), err
+}
+
+func readKnownHosts(t *testing.T, ctx *config.Context) []string {
+	t.Helper()
+
+	file, err := ctx.GetKnownHostsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.Split(strings.TrimSpace(string(b)), "\n")
+}
+
+func TestSSHTransportKeyFile(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, hostKey := newSSHKey(t)
+	userKey, userSigner := newSSHKey(t)
+	endpoint := newSSHServer(t, hostKey, userSigner.PublicKey(), 0)
+
+	ctx := &config.Context{Name: "ssh", Username: "user", Transport: config.TransportSSH, SshEndpoint: endpoint, SshKeyFile: writeSSHKeyFile(t, userKey)}
+
+	code, out, err := runSSH(ctx, []string{"declarative-linter"}, "pipeline {}")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 0 || out != `"declarative-linter" < pipeline {}` {
+		t.Errorf("Run() = %d, %q", code, out)
+	}
+
+	// The host key is trusted on first use and recorded once
+	if _, _, err := runSSH(ctx, []string{"who-am-i"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	want := knownhosts.Line([]string{knownhosts.Normalize(endpoint)}, hostKey.PublicKey())
+	if lines := readKnownHosts(t, ctx); len(lines) != 1 || lines[0] != want {
+		t.Errorf("known_hosts = %q, want %q", lines, want)
+	}
+}
+
+func TestSSHTransportAgent(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, hostKey := newSSHKey(t)
+	userKey, userSigner := newSSHKey(t)
+	endpoint := newSSHServer(t, hostKey, userSigner.PublicKey(), 3)
+
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: userKey}); err != nil {
+		t.Fatal(err)
+	}
+
+	sock := filepath.Join(t.TempDir(), "agent.sock")
+	l, err := net.Listen("unix", sock)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+
+	closed := make(chan struct{})
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		agent.ServeAgent(keyring, conn)
+		close(closed)
+	}()
+
+	t.Setenv("SSH_AUTH_SOCK", sock)
+
+	ctx := &config.Context{Name: "agent", Username: "user", Transport: config.TransportSSH, SshEndpoint: endpoint}
+
+	code, out, err := runSSH(ctx, []string{"build", "a \"b\""}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 3 || out != `"build" "a \"b\"" < ` {
+		t.Errorf("Run() = %d, %q", code, out)
+	}
+
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Error("connection to the SSH agent was not closed")
+	}
+}
+
+func TestSSHTransportHostKeyMismatch(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	_, hostKey := newSSHKey(t)
+	_, otherKey := newSSHKey(t)
+	userKey, userSigner := newSSHKey(t)
+	endpoint := newSSHServer(t, hostKey, userSigner.PublicKey(), 0)
+
+	ctx := &config.Context{Name: "mismatch", Username: "user", Transport: config.TransportSSH, SshEndpoint: endpoint, SshKeyFile: writeSSHKeyFile(t, userKey)}
+
+	file, err := ctx.GetKnownHostsFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	recorded := knownhosts.Line([]string{knownhosts.Normalize(endpoint)}, otherKey.PublicKey())
+	if err := os.MkdirAll(filepath.Dir(file), 0700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(file, []byte(recorded+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, _, err := runSSH(ctx, []string{"who-am-i"}, ""); err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Fatalf("Run() error = %v, want host key mismatch", err)
+	}
+
+	if lines := readKnownHosts(t, ctx); len(lines) != 1 || lines[0] != recorded {
+		t.Errorf("known_hosts = %q, want only %q", lines, recorded)
+	}
+}
@@ -0,0 +1,303 @@
+package jenkins
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// Stage and flow node statuses reported by the workflow REST API
+const (
+	StageSuccess      = "SUCCESS"
+	StageFailed       = "FAILED"
+	StageUnstable     = "UNSTABLE"
+	StageAborted      = "ABORTED"
+	StageInProgress   = "IN_PROGRESS"
+	StagePendingInput = "PAUSED_PENDING_INPUT"
+	StageNotExecuted  = "NOT_EXECUTED"
+)
+
+var htmlTagPattern = regexp.MustCompile(`<[^>]*>`)
+
+type Stage struct {
+	Id              string `json:"id"`
+	Name            string `json:"name"`
+	Status          string `json:"status"`
+	StartTimeMillis int64  `json:"startTimeMillis"`
+	DurationMillis  int64  `json:"durationMillis"`
+
+	// ParentId is the id of the enclosing stage of a nested stage, e.g. a
+	// stage of a parallel branch. It is set by ResolveStageParents.
+	ParentId string `json:"parentId,omitempty"`
+}
+
+// RunDescription is a pipeline build as described by wfapi/describe
+type RunDescription struct {
+	Id              string  `json:"id"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	StartTimeMillis int64   `json:"startTimeMillis"`
+	DurationMillis  int64   `json:"durationMillis"`
+	Stages          []Stage `json:"stages"`
+}
+
+// flowGraphNode is a node of the flow graph of a pipeline build, as exported
+// by the Jenkins API under execution/node/<id>
+type flowGraphNode struct {
+	Class   string   `json:"_class"`
+	Id      string   `json:"id"`
+	Parents []string `json:"parents"`
+}
+
+// isBlockStart reports whether the node starts a block, e.g. a stage,
+// parallel branch or node block
+func (n flowGraphNode) isBlockStart() bool {
+	return strings.HasSuffix(n.Class, ".StepStartNode")
+}
+
+// isBlockEnd reports whether the node ends a block
+func (n flowGraphNode) isBlockEnd() bool {
+	return strings.HasSuffix(n.Class, ".StepEndNode")
+}
+
+type FlowNode struct {
+	Id                   string `json:"id"`
+	Name                 string `json:"name"`
+	Status               string `json:"status"`
+	ParameterDescription string `json:"parameterDescription"`
+}
+
+type NodeLog struct {
+	NodeId     string `json:"nodeId"`
+	NodeStatus string `json:"nodeStatus"`
+	Length     int64  `json:"length"`
+	HasMore    bool   `json:"hasMore"`
+	Text       string `json:"text"`
+	ConsoleUrl string `json:"consoleUrl"`
+}
+
+// Start returns when the stage started
+func (s Stage) Start() time.Time {
+	return time.UnixMilli(s.StartTimeMillis)
+}
+
+// End returns when the stage finished, or the time it was described at if
+// it is still running
+func (s Stage) End() time.Time {
+	return s.Start().Add(s.Duration())
+}
+
+// Duration returns how long the stage ran
+func (s Stage) Duration() time.Duration {
+	return time.Duration(s.DurationMillis) * time.Millisecond
+}
+
+// FindStage returns the stage with the given name, ignoring case
+func (r RunDescription) FindStage(name string) (Stage, error) {
+	for _, s := range r.Stages {
+		if strings.EqualFold(s.Name, name) {
+			return s, nil
+		}
+	}
+
+	names := make([]string, len(r.Stages))
+	for i, s := range r.Stages {
+		names[i] = s.Name
+	}
+
+	return Stage{}, errs.New(errs.KindNotFound, "Stage '%s' not found. Stages of %s are: %s", name, r.Name, strings.Join(names, ", "))
+}
+
+// DescribeRun returns the stages of a pipeline build
+func (c *Client) DescribeRun(ctx context.Context, job string, build string) (RunDescription, error) {
+	var run RunDescription
+	err := c.getJSON(ctx, fmt.Sprintf("%s/%s/wfapi/describe", JobUrlPath(job), BuildRef(build)), nil, &run)
+
+	return run, err
+}
+
+// ResolveStageParents sets the parent of each nested stage of the run from the
+// flow graph of the build
+func (c *Client) ResolveStageParents(ctx context.Context, job string, build string, run *RunDescription) error {
+	nodes := map[string]flowGraphNode{}
+
+	getNode := func(id string) (flowGraphNode, error) {
+		if n, ok := nodes[id]; ok {
+			return n, nil
+		}
+
+		var n flowGraphNode
+		path := fmt.Sprintf("%s/%s/execution/node/%s/api/json", JobUrlPath(job), BuildRef(build), id)
+		if err := c.getJSON(ctx, path, url.Values{"tree": {"id,parents"}}, &n); err != nil {
+			return n, err
+		}
+		nodes[id] = n
+
+		return n, nil
+	}
+
+	return resolveStageParents(run.Stages, getNode)
+}
+
+// resolveStageParents walks up the flow graph from the node of each stage to
+// the innermost enclosing block that is a stage. Blocks that ended before
+// the stage, such as earlier sibling stages, are skipped by matching their
+// end and start nodes. Only stages running within the time span of an
+// earlier stage can be nested, and the walk stops before the node of the
+// earliest of those, so most top-level stages need no requests.
+func resolveStageParents(stages []Stage, getNode func(id string) (flowGraphNode, error)) error {
+	stageIds := map[string]bool{}
+	for _, s := range stages {
+		stageIds[s.Id] = true
+	}
+
+	for i := range stages {
+		bound, ok := walkBound(stages, stages[i])
+		if !ok {
+			continue
+		}
+
+		n, err := getNode(stages[i].Id)
+		if err != nil {
+			return err
+		}
+
+		for depth := 0; len(n.Parents) > 0; {
+			if p := nodeNumber(n.Parents[0]); p >= 0 && p < bound {
+				break
+			}
+
+			if n, err = getNode(n.Parents[0]); err != nil {
+				return err
+			}
+
+			if n.isBlockEnd() {
+				depth++
+			} else if n.isBlockStart() {
+				if depth > 0 {
+					depth--
+				} else if stageIds[n.Id] {
+					stages[i].ParentId = n.Id
+					break
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkBound returns the number of the node of the earliest stage that
+// started before the stage and whose time span contains it, which is as far
+// as the walk from the stage has to go. It reports false if no stage can
+// enclose the stage, and a bound of 0 if the node ids are not numbers.
+func walkBound(stages []Stage, s Stage) (int, bool) {
+	bound, found := 0, false
+
+	for _, t := range stages {
+		if t.Id == s.Id || t.Start().After(s.Start()) || t.End().Before(s.End()) {
+			continue
+		}
+
+		n, m := nodeNumber(t.Id), nodeNumber(s.Id)
+		if n < 0 || m < 0 {
+			return 0, true
+		}
+
+		if n < m && (!found || n < bound) {
+			bound, found = n, true
+		}
+	}
+
+	return bound, found
+}
+
+// nodeNumber returns the number of a flow node, or -1 if its id is not a
+// number. Nodes are numbered in the order they are created.
+func nodeNumber(id string) int {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return -1
+	}
+
+	return n
+}
+
+// GetStageNodes returns the flow nodes, i.e. steps, of a stage
+func (c *Client) GetStageNodes(ctx context.Context, job string, build string, stageId string) ([]FlowNode, error) {
+	var resp struct {
+		StageFlowNodes []FlowNode `json:"stageFlowNodes"`
+	}
+
+	err := c.getJSON(ctx, fmt.Sprintf("%s/%s/execution/node/%s/wfapi/describe", JobUrlPath(job), BuildRef(build), stageId), nil, &resp)
+
+	return resp.StageFlowNodes, err
+}
+
+// GetNodeLog returns the log of a flow node
+func (c *Client) GetNodeLog(ctx context.Context, job string, build string, nodeId string) (NodeLog, error) {
+	var nodeLog NodeLog
+	err := c.getJSON(ctx, fmt.Sprintf("%s/%s/execution/node/%s/wfapi/log", JobUrlPath(job), BuildRef(build), nodeId), nil, &nodeLog)
+
+	return nodeLog, err
+}
+
+// stageLog writes the logs of the steps of the named stage to w. The
+// workflow API truncates long step logs, in which case a link to the full
+// log is written instead of the rest.
+func (c *Client) stageLog(ctx context.Context, job string, build string, w io.Writer, opts LogOptions) error {
+	run, err := c.DescribeRun(ctx, job, build)
+	if err != nil {
+		return err
+	}
+
+	stage, err := run.FindStage(opts.Stage)
+	if err != nil {
+		return err
+	}
+
+	nodes, err := c.GetStageNodes(ctx, job, build, stage.Id)
+	if err != nil {
+		return err
+	}
+
+	var b strings.Builder
+
+	for _, n := range nodes {
+		nodeLog, err := c.GetNodeLog(ctx, job, build, n.Id)
+		if err != nil {
+			return err
+		}
+
+		if nodeLog.Text == "" {
+			continue
+		}
+
+		text := html.UnescapeString(htmlTagPattern.ReplaceAllString(nodeLog.Text, ""))
+		if !strings.HasSuffix(text, "\n") {
+			text += "\n"
+		}
+		b.WriteString(text)
+
+		if nodeLog.HasMore {
+			fmt.Fprintf(&b, "[log of step %s truncated, see %s%s]\n", n.Id, c.api.Server, nodeLog.ConsoleUrl)
+		}
+	}
+
+	text := b.String()
+	if opts.Tail > 0 {
+		text = tail(text, opts.Tail)
+	}
+
+	_, err = io.WriteString(w, text)
+
+	return err
+}
@@ -0,0 +1,112 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+// newFlowGraphClient serves the wfapi/describe fixture of build 7 of
+// app/main and the nodes of its flow graph, counting the requests per node
+func newFlowGraphClient(t *testing.T, describe string, nodes string) (*Client, map[string]int) {
+	t.Helper()
+
+	describeJSON, err := os.ReadFile(describe)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	nodesJSON, err := os.ReadFile(nodes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var graph []json.RawMessage
+	if err := json.Unmarshal(nodesJSON, &graph); err != nil {
+		t.Fatal(err)
+	}
+
+	byId := map[string]json.RawMessage{}
+	for _, raw := range graph {
+		var n flowGraphNode
+		if err := json.Unmarshal(raw, &n); err != nil {
+			t.Fatal(err)
+		}
+		byId[n.Id] = raw
+	}
+
+	const prefix = "/job/app/job/main/7/"
+	requests := map[string]int{}
+
+	return newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, prefix)
+
+		switch {
+		case path == "wfapi/describe":
+			w.Write(describeJSON)
+		case strings.HasPrefix(path, "execution/node/") && strings.HasSuffix(path, "/api/json"):
+			id := strings.TrimSuffix(strings.TrimPrefix(path, "execution/node/"), "/api/json")
+			requests[id]++
+			if raw, ok := byId[id]; ok {
+				w.Write(raw)
+				return
+			}
+			http.NotFound(w, r)
+		default:
+			http.NotFound(w, r)
+		}
+	})), requests
+}
+
+func TestResolveStageParents(t *testing.T) {
+	c, requests := newFlowGraphClient(t, "testdata/wfapi-describe-parallel.json", "testdata/flow-nodes-parallel.json")
+
+	ctx := context.Background()
+	run, err := c.DescribeRun(ctx, "app/main", "7")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := c.ResolveStageParents(ctx, "app/main", "7", &run); err != nil {
+		t.Fatal(err)
+	}
+
+	// Checkout and Build start at the same time, and Integration runs
+	// within the time span of its parallel sibling Unit
+	want := map[string]string{
+		"Checkout":    "",
+		"Build":       "",
+		"Tests":       "",
+		"Unit":        "14",
+		"Integration": "14",
+		"Deploy":      "",
+	}
+
+	if len(run.Stages) != len(want) {
+		t.Fatalf("got %d stages, want %d", len(run.Stages), len(want))
+	}
+
+	for _, s := range run.Stages {
+		if s.ParentId != want[s.Name] {
+			t.Errorf("parent of stage %s = %q, want %q", s.Name, s.ParentId, want[s.Name])
+		}
+	}
+
+	// Stages outside the time span of other stages are not walked, and no
+	// walk goes past the start of the Tests stage
+	for id, n := range requests {
+		if nodeNumber(id) < 14 {
+			t.Errorf("requested node %s, which precedes every enclosing stage", id)
+		}
+		if n > 1 {
+			t.Errorf("requested node %s %d times", id, n)
+		}
+	}
+
+	if requests["34"] > 0 {
+		t.Errorf("requested node 34 of the top-level stage Deploy")
+	}
+}
@@ -0,0 +1,221 @@
+package jenkins
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+)
+
+// Test case statuses
+const (
+	TestPassed     = "PASSED"
+	TestFixed      = "FIXED"
+	TestFailed     = "FAILED"
+	TestRegression = "REGRESSION"
+	TestSkipped    = "SKIPPED"
+)
+
+type TestReport struct {
+	Duration  float64     `json:"duration"`
+	FailCount int         `json:"failCount"`
+	PassCount int         `json:"passCount"`
+	SkipCount int         `json:"skipCount"`
+	Suites    []TestSuite `json:"suites"`
+}
+
+type TestSuite struct {
+	Name     string     `json:"name"`
+	Duration float64    `json:"duration"`
+	Cases    []TestCase `json:"cases"`
+}
+
+type TestCase struct {
+	ClassName       string  `json:"className"`
+	Name            string  `json:"name"`
+	Status          string  `json:"status"`
+	Duration        float64 `json:"duration"`
+	ErrorDetails    string  `json:"errorDetails,omitempty"`
+	ErrorStackTrace string  `json:"errorStackTrace,omitempty"`
+}
+
+// GetTestReport returns the test results of a build
+func (c *Client) GetTestReport(ctx context.Context, job string, build string) (TestReport, error) {
+	var report TestReport
+
+	query := url.Values{"tree": {"duration,failCount,passCount,skipCount,suites[name,duration,cases[className,name,status,duration,errorDetails,errorStackTrace]]"}}
+	err := c.getJSON(ctx, fmt.Sprintf("%s/%s/testReport/api/json", JobUrlPath(job), BuildRef(build)), query, &report)
+	if err != nil {
+		return report, fmt.Errorf("No test report for %s #%s: %w", job, BuildRef(build), err)
+	}
+
+	return report, nil
+}
+
+// FullName returns the class and name of the test case
+func (t TestCase) FullName() string {
+	if t.ClassName == "" {
+		return t.Name
+	}
+
+	return t.ClassName + "." + t.Name
+}
+
+// IsFailed reports whether the test case failed
+func (t TestCase) IsFailed() bool {
+	return isFailedStatus(t.Status)
+}
+
+func isFailedStatus(status string) bool {
+	return status == TestFailed || status == TestRegression
+}
+
+// IsSkipped reports whether the test case was skipped
+func (t TestCase) IsSkipped() bool {
+	return t.Status == TestSkipped
+}
+
+// Counts returns the number of passed, failed and skipped test cases
+func (s TestSuite) Counts() (passed int, failed int, skipped int) {
+	for _, t := range s.Cases {
+		switch {
+		case t.IsFailed():
+			failed++
+		case t.IsSkipped():
+			skipped++
+		default:
+			passed++
+		}
+	}
+
+	return passed, failed, skipped
+}
+
+// Failed returns the failed test cases of all suites
+func (r TestReport) Failed() []TestCase {
+	var failed []TestCase
+	for _, s := range r.Suites {
+		for _, t := range s.Cases {
+			if t.IsFailed() {
+				failed = append(failed, t)
+			}
+		}
+	}
+
+	return failed
+}
+
+// statuses maps the full names of the test cases to their status
+func (r TestReport) statuses() map[string]string {
+	statuses := map[string]string{}
+	for _, s := range r.Suites {
+		for _, t := range s.Cases {
+			statuses[t.FullName()] = t.Status
+		}
+	}
+
+	return statuses
+}
+
+// TestComparison lists the tests whose outcome changed between two builds
+type TestComparison struct {
+	NewlyFailing []string `json:"newlyFailing"`
+	NewlyFixed   []string `json:"newlyFixed"`
+}
+
+// CompareTestReports returns the tests failing in report which did not fail
+// in base, and the tests failing in base which no longer fail in report.
+// Tests missing from report count as fixed, while skipped tests do not.
+func CompareTestReports(base TestReport, report TestReport) TestComparison {
+	before := base.statuses()
+	after := report.statuses()
+
+	comparison := TestComparison{NewlyFailing: []string{}, NewlyFixed: []string{}}
+
+	for name, status := range after {
+		if isFailedStatus(status) && !isFailedStatus(before[name]) {
+			comparison.NewlyFailing = append(comparison.NewlyFailing, name)
+		}
+	}
+
+	for name, status := range before {
+		if !isFailedStatus(status) {
+			continue
+		}
+
+		if now, ok := after[name]; !ok || (!isFailedStatus(now) && now != TestSkipped) {
+			comparison.NewlyFixed = append(comparison.NewlyFixed, name)
+		}
+	}
+
+	sort.Strings(comparison.NewlyFailing)
+	sort.Strings(comparison.NewlyFixed)
+
+	return comparison
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Tests   int              `xml:"tests,attr"`
+	Failed  int              `xml:"failures,attr"`
+	Skipped int              `xml:"skipped,attr"`
+	Time    float64          `xml:"time,attr"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name    string          `xml:"name,attr"`
+	Tests   int             `xml:"tests,attr"`
+	Failed  int             `xml:"failures,attr"`
+	Skipped int             `xml:"skipped,attr"`
+	Time    float64         `xml:"time,attr"`
+	Cases   []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Time      float64       `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *struct{}     `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message    string `xml:"message,attr,omitempty"`
+	StackTrace string `xml:",cdata"`
+}
+
+// JUnit encodes the report as JUnit XML
+func (r TestReport) JUnit() ([]byte, error) {
+	suites := junitTestSuites{
+		Tests:   r.PassCount + r.FailCount + r.SkipCount,
+		Failed:  r.FailCount,
+		Skipped: r.SkipCount,
+		Time:    r.Duration,
+	}
+
+	for _, s := range r.Suites {
+		passed, failed, skipped := s.Counts()
+		suite := junitTestSuite{Name: s.Name, Tests: passed + failed + skipped, Failed: failed, Skipped: skipped, Time: s.Duration}
+
+		for _, t := range s.Cases {
+			tc := junitTestCase{ClassName: t.ClassName, Name: t.Name, Time: t.Duration}
+			if t.IsFailed() {
+				tc.Failure = &junitFailure{Message: t.ErrorDetails, StackTrace: t.ErrorStackTrace}
+			} else if t.IsSkipped() {
+				tc.Skipped = &struct{}{}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	out, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
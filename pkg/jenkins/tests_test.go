@@ -0,0 +1,84 @@
+package jenkins
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testReport(cases ...TestCase) TestReport {
+	return TestReport{Suites: []TestSuite{{Name: "suite", Cases: cases}}}
+}
+
+func TestCompareTestReports(t *testing.T) {
+	base := testReport(
+		TestCase{ClassName: "a", Name: "stillFailing", Status: TestFailed},
+		TestCase{ClassName: "a", Name: "fixed", Status: TestRegression},
+		TestCase{ClassName: "a", Name: "removed", Status: TestFailed},
+		TestCase{ClassName: "a", Name: "skippedNow", Status: TestFailed},
+		TestCase{ClassName: "a", Name: "broken", Status: TestPassed},
+		TestCase{ClassName: "a", Name: "passing", Status: TestPassed},
+	)
+
+	report := testReport(
+		TestCase{ClassName: "a", Name: "stillFailing", Status: TestFailed},
+		TestCase{ClassName: "a", Name: "fixed", Status: TestFixed},
+		TestCase{ClassName: "a", Name: "skippedNow", Status: TestSkipped},
+		TestCase{ClassName: "a", Name: "broken", Status: TestRegression},
+		TestCase{ClassName: "a", Name: "passing", Status: TestPassed},
+		TestCase{ClassName: "a", Name: "added", Status: TestFailed},
+	)
+
+	want := TestComparison{
+		NewlyFailing: []string{"a.added", "a.broken"},
+		NewlyFixed:   []string{"a.fixed", "a.removed"},
+	}
+
+	if got := CompareTestReports(base, report); !reflect.DeepEqual(got, want) {
+		t.Errorf("CompareTestReports() = %+v, want %+v", got, want)
+	}
+
+	if got := CompareTestReports(report, report); len(got.NewlyFailing) != 0 || len(got.NewlyFixed) != 0 {
+		t.Errorf("CompareTestReports() of the same report = %+v, want no changes", got)
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	report := TestReport{
+		Duration:  1.5,
+		PassCount: 1,
+		FailCount: 1,
+		SkipCount: 1,
+		Suites: []TestSuite{{
+			Name:     "com.example.AppTest",
+			Duration: 1.5,
+			Cases: []TestCase{
+				{ClassName: "com.example.AppTest", Name: "passes", Status: TestPassed, Duration: 0.5},
+				{ClassName: "com.example.AppTest", Name: "fails", Status: TestRegression, Duration: 1, ErrorDetails: `expected "a" <b>`, ErrorStackTrace: "at App.java:1"},
+				{ClassName: "com.example.AppTest", Name: "skips", Status: TestSkipped},
+			},
+		}},
+	}
+
+	out, err := report.JUnit()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites tests="3" failures="1" skipped="1" time="1.5">
+  <testsuite name="com.example.AppTest" tests="3" failures="1" skipped="1" time="1.5">
+    <testcase classname="com.example.AppTest" name="passes" time="0.5"></testcase>
+    <testcase classname="com.example.AppTest" name="fails" time="1">
+      <failure message="expected &#34;a&#34; &lt;b&gt;"><![CDATA[at App.java:1]]></failure>
+    </testcase>
+    <testcase classname="com.example.AppTest" name="skips" time="0">
+      <skipped></skipped>
+    </testcase>
+  </testsuite>
+</testsuites>
+`
+
+	if string(out) != want {
+		t.Errorf("JUnit() =\n%s\nwant\n%s", out, want)
+	}
+}
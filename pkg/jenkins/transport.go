@@ -0,0 +1,33 @@
+package jenkins
+
+import (
+	"context"
+	"io"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// Transport runs a Jenkins CLI command on the server
+type Transport interface {
+	// Run executes the command with the given arguments and returns its exit code
+	Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error)
+}
+
+// NewTransport returns the transport selected by the context. Progress
+// messages of the transport itself go to the streams.
+func NewTransport(ctx *config.Context, streams *utils.IOStreams) (Transport, error) {
+	transport, err := ctx.GetTransport()
+	if err != nil {
+		return nil, err
+	}
+
+	switch transport {
+	case config.TransportJar:
+		return jarTransport{cli: NewJenkinsCli(ctx, streams)}, nil
+	case config.TransportSSH:
+		return sshTransport{ctx: ctx}, nil
+	default:
+		return webSocketTransport{ctx: ctx}, nil
+	}
+}
@@ -0,0 +1,289 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	neturl "net/url"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	log "github.com/sirupsen/logrus"
+	"golang.org/x/net/websocket"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+// Operations of the Jenkins CLI protocol, see hudson.cli.PlainCLIProtocol.Op
+const (
+	opArg byte = iota
+	opLocale
+	opEncoding
+	opStart
+	opExit
+	opStdin
+	opEndStdin
+	opStdout
+	opStderr
+)
+
+// stdinChunkSize is the maximum payload size of a single stdin frame
+const stdinChunkSize = 8192
+
+// webSocketTransport speaks the Jenkins CLI protocol over the /cli/ws
+// endpoint, the same way 'java -jar cli.jar -webSocket' does.
+type webSocketTransport struct {
+	ctx *config.Context
+}
+
+func (t webSocketTransport) Run(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) (int, error) {
+	ws, err := t.dial(ctx)
+	if err != nil {
+		return -1, err
+	}
+	defer ws.Close()
+
+	// Closing the connection aborts the command on the server
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			ws.Close()
+		case <-done:
+		}
+	}()
+
+	for _, arg := range args {
+		if err := sendUTF(ws, opArg, arg); err != nil {
+			return -1, err
+		}
+	}
+
+	if err := sendUTF(ws, opEncoding, "UTF-8"); err != nil {
+		return -1, err
+	}
+
+	if err := sendUTF(ws, opLocale, "en_US"); err != nil {
+		return -1, err
+	}
+
+	if err := sendFrame(ws, opStart, nil); err != nil {
+		return -1, err
+	}
+
+	stdinErr := make(chan error, 1)
+	go func() {
+		stdinErr <- sendStdin(ws, stdin)
+	}()
+
+	for {
+		var msg []byte
+		if err := websocket.Message.Receive(ws, &msg); err != nil {
+			if ctx.Err() != nil {
+				return -1, ctx.Err()
+			}
+			if err == io.EOF {
+				return -1, fmt.Errorf("connection closed by %s before the command exited", t.ctx.Host)
+			}
+			return -1, err
+		}
+
+		op, data, err := decodeFrame(msg)
+		if err != nil {
+			return -1, err
+		}
+
+		switch op {
+		case opStdout:
+			if _, err := stdout.Write(data); err != nil {
+				return -1, err
+			}
+		case opStderr:
+			if _, err := stderr.Write(data); err != nil {
+				return -1, err
+			}
+		case opExit:
+			if len(data) < 4 {
+				return -1, fmt.Errorf("invalid exit frame from %s", t.ctx.Host)
+			}
+			select {
+			case err := <-stdinErr:
+				if err != nil {
+					log.Debug("Sending stdin failed: ", err)
+				}
+			default:
+			}
+			return int(int32(binary.BigEndian.Uint32(data))), nil
+		default:
+			log.Debug("Ignoring unexpected CLI frame with op ", op)
+		}
+	}
+}
+
+func (t webSocketTransport) dial(ctx context.Context) (*websocket.Conn, error) {
+	host := strings.TrimSuffix(t.ctx.Host, "/")
+
+	var url string
+	switch {
+	case strings.HasPrefix(host, "https://"):
+		url = "wss://" + strings.TrimPrefix(host, "https://") + "/cli/ws"
+	case strings.HasPrefix(host, "http://"):
+		url = "ws://" + strings.TrimPrefix(host, "http://") + "/cli/ws"
+	default:
+		return nil, errs.New(errs.KindConfig, "Invalid Jenkins URL '%s' for context '%s'", t.ctx.Host, t.ctx.Name)
+	}
+
+	cfg, err := websocket.NewConfig(url, host)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := t.ctx.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	auth := base64.StdEncoding.EncodeToString([]byte(t.ctx.Username + ":" + token))
+	cfg.Header.Set("Authorization", "Basic "+auth)
+
+	log.Debug("Connecting to ", url)
+
+	conn, err := dialContext(ctx, cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", url, err)
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	ws, err := websocket.NewClient(cfg, conn)
+	if err != nil {
+		conn.Close()
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("unable to connect to %s: %w", url, err)
+	}
+
+	conn.SetDeadline(time.Time{})
+
+	return ws, nil
+}
+
+// dialContext opens a TCP connection to the WebSocket location, using TLS
+// for wss URLs.
+func dialContext(ctx context.Context, location *neturl.URL) (net.Conn, error) {
+	addr := location.Host
+	if location.Port() == "" {
+		if location.Scheme == "wss" {
+			addr = net.JoinHostPort(location.Hostname(), "443")
+		} else {
+			addr = net.JoinHostPort(location.Hostname(), "80")
+		}
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil || location.Scheme != "wss" {
+		return conn, err
+	}
+
+	tlsConn := tls.Client(conn, &tls.Config{ServerName: location.Hostname()})
+	if err := tlsConn.HandshakeContext(ctx); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return tlsConn, nil
+}
+
+func sendStdin(ws *websocket.Conn, stdin io.Reader) error {
+	if stdin != nil {
+		buf := make([]byte, stdinChunkSize)
+		for {
+			n, err := stdin.Read(buf)
+			if n > 0 {
+				if err := sendFrame(ws, opStdin, buf[:n]); err != nil {
+					return err
+				}
+			}
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+		}
+	}
+
+	return sendFrame(ws, opEndStdin, nil)
+}
+
+// sendUTF sends a frame carrying a string
+func sendUTF(ws *websocket.Conn, op byte, s string) error {
+	data, err := encodeUTF(s)
+	if err != nil {
+		return err
+	}
+
+	return sendFrame(ws, op, data)
+}
+
+// sendFrame sends a single protocol frame as one binary message
+func sendFrame(ws *websocket.Conn, op byte, data []byte) error {
+	return websocket.Message.Send(ws, encodeFrame(op, data))
+}
+
+// encodeFrame encodes a frame as sent over WebSocket: the operation followed
+// by the payload. Unlike the -http mode, frames carry no length prefix since
+// every message holds exactly one frame.
+func encodeFrame(op byte, data []byte) []byte {
+	return append([]byte{op}, data...)
+}
+
+func decodeFrame(frame []byte) (byte, []byte, error) {
+	if len(frame) < 1 {
+		return 0, nil, fmt.Errorf("invalid empty CLI frame")
+	}
+
+	return frame[0], frame[1:], nil
+}
+
+// encodeUTF encodes a string like java.io.DataOutputStream.writeUTF, in
+// modified UTF-8 prefixed with its length as a 16-bit big-endian integer.
+func encodeUTF(s string) ([]byte, error) {
+	var b bytes.Buffer
+	b.Write([]byte{0, 0})
+
+	for _, c := range utf16.Encode([]rune(s)) {
+		switch {
+		case c >= 0x01 && c <= 0x7f:
+			b.WriteByte(byte(c))
+		case c <= 0x7ff:
+			b.WriteByte(byte(0xc0 | c>>6))
+			b.WriteByte(byte(0x80 | c&0x3f))
+		default:
+			b.WriteByte(byte(0xe0 | c>>12))
+			b.WriteByte(byte(0x80 | c>>6&0x3f))
+			b.WriteByte(byte(0x80 | c&0x3f))
+		}
+	}
+
+	n := b.Len() - 2
+	if n > 0xffff {
+		return nil, errs.New(errs.KindValidation, "CLI argument too long: %d bytes", n)
+	}
+
+	frame := b.Bytes()
+	binary.BigEndian.PutUint16(frame, uint16(n))
+
+	return frame, nil
+}
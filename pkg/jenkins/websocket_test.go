@@ -0,0 +1,123 @@
+package jenkins
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"golang.org/x/net/websocket"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+)
+
+func TestEncodeUTF(t *testing.T) {
+	tests := []struct {
+		s    string
+		want []byte
+	}{
+		{"", []byte{0x00, 0x00}},
+		{"UTF-8", []byte{0x00, 0x05, 'U', 'T', 'F', '-', '8'}},
+		{"a\x00b", []byte{0x00, 0x04, 'a', 0xc0, 0x80, 'b'}},
+		{"é", []byte{0x00, 0x02, 0xc3, 0xa9}},
+		{"€", []byte{0x00, 0x03, 0xe2, 0x82, 0xac}},
+		{"😀", []byte{0x00, 0x06, 0xed, 0xa0, 0xbd, 0xed, 0xb8, 0x80}},
+	}
+
+	for _, tt := range tests {
+		got, err := encodeUTF(tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, tt.want) {
+			t.Errorf("encodeUTF(%q) = % x, want % x", tt.s, got, tt.want)
+		}
+	}
+
+	if _, err := encodeUTF(strings.Repeat("a", 0x10000)); err == nil {
+		t.Error("encodeUTF() of 64 KiB succeeded, want error")
+	}
+}
+
+func TestFrames(t *testing.T) {
+	frame := encodeFrame(opStdout, []byte("ok\n"))
+	if want := []byte{0x07, 'o', 'k', '\n'}; !bytes.Equal(frame, want) {
+		t.Errorf("encodeFrame() = % x, want % x", frame, want)
+	}
+
+	op, data, err := decodeFrame([]byte{0x04, 0x00, 0x00, 0x00, 0x01})
+	if err != nil || op != opExit || !bytes.Equal(data, []byte{0x00, 0x00, 0x00, 0x01}) {
+		t.Errorf("decodeFrame() = %d, % x, %v", op, data, err)
+	}
+
+	if _, _, err := decodeFrame(nil); err == nil {
+		t.Error("decodeFrame() of an empty message succeeded, want error")
+	}
+}
+
+// TestWebSocketTransport runs a command against a stand-in /cli/ws endpoint
+// exchanging the messages of a 'java -jar cli.jar -webSocket' session, one
+// frame per binary message as in hudson.cli.PlainCLIProtocol.
+func TestWebSocketTransport(t *testing.T) {
+	wantReceived := [][]byte{
+		{0x00, 0x00, 0x12, 'd', 'e', 'c', 'l', 'a', 'r', 'a', 't', 'i', 'v', 'e', '-', 'l', 'i', 'n', 't', 'e', 'r'},
+		{0x02, 0x00, 0x05, 'U', 'T', 'F', '-', '8'},
+		{0x01, 0x00, 0x05, 'e', 'n', '_', 'U', 'S'},
+		{0x03},
+		{0x05, 'p', 'i', 'p', 'e', 'l', 'i', 'n', 'e', ' ', '{', '}', '\n'},
+		{0x06},
+	}
+
+	var received [][]byte
+	var auth string
+
+	mux := http.NewServeMux()
+	mux.Handle("/cli/ws", websocket.Handler(func(ws *websocket.Conn) {
+		auth = ws.Request().Header.Get("Authorization")
+
+		for {
+			var msg []byte
+			if err := websocket.Message.Receive(ws, &msg); err != nil {
+				return
+			}
+			received = append(received, msg)
+			if msg[0] == opEndStdin {
+				break
+			}
+		}
+
+		websocket.Message.Send(ws, []byte{0x07, 'E', 'r', 'r', 'o', 'r', 's', '\n'})
+		websocket.Message.Send(ws, []byte{0x08, 'w', 'a', 'r', 'n', '\n'})
+		websocket.Message.Send(ws, []byte{0x04, 0x00, 0x00, 0x00, 0x01})
+	}))
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	ctx := &config.Context{Name: "test", Host: srv.URL, Username: "user", ApiToken: "token"}
+
+	var stdout, stderr bytes.Buffer
+	code, err := webSocketTransport{ctx: ctx}.Run(context.Background(), []string{"declarative-linter"}, strings.NewReader("pipeline {}\n"), &stdout, &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+
+	if stdout.String() != "Errors\n" || stderr.String() != "warn\n" {
+		t.Errorf("stdout = %q, stderr = %q", stdout.String(), stderr.String())
+	}
+
+	if !reflect.DeepEqual(received, wantReceived) {
+		t.Errorf("server received\n% x\nwant\n% x", received, wantReceived)
+	}
+
+	if want := "Basic dXNlcjp0b2tlbg=="; auth != want {
+		t.Errorf("Authorization = %q, want %q", auth, want)
+	}
+}
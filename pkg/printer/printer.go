@@ -0,0 +1,183 @@
+package printer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// Output formats
+const (
+	FormatTable    = "table"
+	FormatJSON     = "json"
+	FormatYAML     = "yaml"
+	FormatTemplate = "template"
+)
+
+// Table is implemented by results which can be printed as a table
+type Table interface {
+	Header() []string
+	Rows() [][]string
+}
+
+// Printer writes command results in the output format selected with the
+// global --output flag.
+type Printer struct {
+	Format   string
+	template *template.Template
+	out      io.Writer
+}
+
+// New returns a printer for an output format, which is one of json, yaml,
+// table, or template=<Go template>. The empty format prints tables.
+func New(output string, out io.Writer) (*Printer, error) {
+	p := &Printer{Format: output, out: out}
+
+	if output == "" {
+		p.Format = FormatTable
+	}
+
+	if strings.HasPrefix(output, FormatTemplate+"=") {
+		text := strings.TrimPrefix(output, FormatTemplate+"=")
+		if text == "" {
+			return nil, errs.New(errs.KindValidation, "Empty output template. Please provide one as template=<template>")
+		}
+
+		t, err := template.New("output").Parse(text)
+		if err != nil {
+			return nil, errs.New(errs.KindValidation, "Invalid output template: %s", err)
+		}
+
+		p.Format = FormatTemplate
+		p.template = t
+	}
+
+	// A bare template format has no template to execute
+	switch {
+	case p.Format == FormatTable, p.Format == FormatJSON, p.Format == FormatYAML:
+	case p.Format == FormatTemplate && p.template != nil:
+	default:
+		return nil, errs.New(errs.KindValidation, "Unknown output format '%s'. Valid formats are: json, yaml, table, template=<template>", output)
+	}
+
+	// Colors would corrupt machine-readable output
+	if p.IsStructured() {
+		color.NoColor = true
+	}
+
+	return p, nil
+}
+
+// FromCommand returns a printer writing to the output stream in the format
+// of the command's --output flag.
+func FromCommand(cmd *cobra.Command, streams *utils.IOStreams) (*Printer, error) {
+	output := ""
+	if f := cmd.Flag("output"); f != nil {
+		output = f.Value.String()
+	}
+
+	return New(output, streams.Out)
+}
+
+// IsStructured reports whether results are printed in a machine-readable
+// format rather than for humans.
+func (p *Printer) IsStructured() bool {
+	return p.Format != FormatTable
+}
+
+// RequireTable returns a validation error if a structured format is
+// selected, for commands whose output is only meant for humans.
+func (p *Printer) RequireTable(cmd *cobra.Command) error {
+	if p.IsStructured() {
+		return errs.New(errs.KindValidation, "'%s' does not support the %s output format", cmd.CommandPath(), p.Format)
+	}
+
+	return nil
+}
+
+// Print writes v in the output format. Tables are printed for values
+// implementing Table, templates are executed for each element of slices.
+func (p *Printer) Print(v interface{}) error {
+	switch p.Format {
+	case FormatJSON:
+		enc := json.NewEncoder(p.out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(v)
+
+	case FormatYAML:
+		y, err := yaml.Marshal(v)
+		if err != nil {
+			return err
+		}
+		_, err = p.out.Write(y)
+		return err
+
+	case FormatTemplate:
+		return p.printTemplate(v)
+	}
+
+	if t, ok := v.(Table); ok {
+		return p.PrintTable(t.Header(), t.Rows())
+	}
+
+	return errs.New(errs.KindValidation, "Results cannot be printed as a table")
+}
+
+// PrintTable writes rows as aligned columns under the header
+func (p *Printer) PrintTable(header []string, rows [][]string) error {
+	w := tabwriter.NewWriter(p.out, 0, 0, 2, ' ', 0)
+
+	if len(header) > 0 {
+		fmt.Fprintln(w, strings.Join(header, "\t"))
+	}
+
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+
+	return w.Flush()
+}
+
+func (p *Printer) printTemplate(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return p.executeTemplate(v)
+	}
+
+	for i := 0; i < rv.Len(); i++ {
+		if err := p.executeTemplate(rv.Index(i).Interface()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// executeTemplate executes the template on v, ending the output with a
+// newline if the template does not.
+func (p *Printer) executeTemplate(v interface{}) error {
+	var b strings.Builder
+	if err := p.template.Execute(&b, v); err != nil {
+		return err
+	}
+
+	s := b.String()
+	if !strings.HasSuffix(s, "\n") {
+		s += "\n"
+	}
+
+	_, err := io.WriteString(p.out, s)
+
+	return err
+}
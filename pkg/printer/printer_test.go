@@ -0,0 +1,95 @@
+package printer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+type row struct {
+	Name string `json:"name"`
+	Host string `json:"host"`
+}
+
+type rows []row
+
+func (r rows) Header() []string {
+	return []string{"NAME", "HOST"}
+}
+
+func (r rows) Rows() [][]string {
+	var out [][]string
+	for _, x := range r {
+		out = append(out, []string{x.Name, x.Host})
+	}
+
+	return out
+}
+
+func TestPrint(t *testing.T) {
+	v := rows{{"ci", "https://ci.example.com"}, {"prod", "https://jenkins.example.com"}}
+
+	tests := []struct {
+		output string
+		want   string
+	}{
+		{"", "NAME  HOST\nci    https://ci.example.com\nprod  https://jenkins.example.com\n"},
+		{"json", "[\n  {\n    \"name\": \"ci\",\n    \"host\": \"https://ci.example.com\"\n  },\n  {\n    \"name\": \"prod\",\n    \"host\": \"https://jenkins.example.com\"\n  }\n]\n"},
+		{"yaml", "- host: https://ci.example.com\n  name: ci\n- host: https://jenkins.example.com\n  name: prod\n"},
+		{"template={{.Name}}", "ci\nprod\n"},
+	}
+
+	for _, tt := range tests {
+		var out bytes.Buffer
+
+		p, err := New(tt.output, &out)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := p.Print(v); err != nil {
+			t.Fatal(err)
+		}
+
+		if out.String() != tt.want {
+			t.Errorf("Print() with output %q = %q, want %q", tt.output, out.String(), tt.want)
+		}
+	}
+}
+
+func TestNewInvalidFormat(t *testing.T) {
+	for _, output := range []string{"xml", "template", "template=", "template={{.Name"} {
+		if _, err := New(output, &bytes.Buffer{}); errs.KindOf(err) != errs.KindValidation {
+			t.Errorf("New(%q) error = %v, want a validation error", output, err)
+		}
+	}
+}
+
+func TestNewBareTemplate(t *testing.T) {
+	_, err := New("template", &bytes.Buffer{})
+	if err == nil || !strings.Contains(err.Error(), "Unknown output format 'template'") {
+		t.Errorf("New(\"template\") error = %v, want an unknown output format error", err)
+	}
+}
+
+func TestRequireTable(t *testing.T) {
+	cmd := &cobra.Command{Use: "logs"}
+
+	for output, structured := range map[string]bool{"": false, "table": false, "json": true, "template={{.}}": true} {
+		p, err := New(output, &bytes.Buffer{})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = p.RequireTable(cmd)
+		if structured && errs.KindOf(err) != errs.KindValidation {
+			t.Errorf("RequireTable() with output %q = %v, want a validation error", output, err)
+		} else if !structured && err != nil {
+			t.Errorf("RequireTable() with output %q = %v", output, err)
+		}
+	}
+}
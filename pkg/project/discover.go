@@ -0,0 +1,172 @@
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	log "github.com/sirupsen/logrus"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/git"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+const JobCacheFile = "jobs.yaml"
+
+// DiscoveryDepth is how deep folders are searched for multibranch jobs
+var DiscoveryDepth = 3
+
+var (
+	remotePattern     = regexp.MustCompile(`<(?:remote|repositoryUrl)>\s*([^<]+?)\s*</(?:remote|repositoryUrl)>`)
+	repoOwnerPattern  = regexp.MustCompile(`<repoOwner>\s*([^<]+?)\s*</repoOwner>`)
+	repositoryPattern = regexp.MustCompile(`<repository>\s*([^<]+?)\s*</repository>`)
+)
+
+// jobCache maps normalized git remotes to multibranch job full names
+type jobCache map[string]string
+
+func getJobCacheFile(ctx config.Context) (string, error) {
+	dir, err := ctx.GetContextDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, JobCacheFile), nil
+}
+
+func readJobCache(ctx config.Context) (jobCache, error) {
+	f, err := getJobCacheFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := jobCache{}
+
+	y, err := os.ReadFile(f)
+	if os.IsNotExist(err) {
+		return cache, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(y, &cache); err != nil {
+		log.Debug("Ignoring invalid job cache ", f, ": ", err)
+		return jobCache{}, nil
+	}
+
+	return cache, nil
+}
+
+func (c jobCache) save(ctx config.Context) error {
+	f, err := getJobCacheFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f), 0700); err != nil {
+		return err
+	}
+
+	y, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f, y, 0600)
+}
+
+// DiscoverJob finds the multibranch job building the current repository by
+// matching its git remotes against the branch sources of the jobs on the
+// server. Results are cached per context unless refresh is set.
+func DiscoverJob(ctx context.Context, streams *utils.IOStreams, jenkinsCtx config.Context, refresh bool) (string, error) {
+	remotes, err := git.Remotes()
+	if err != nil {
+		return "", err
+	}
+
+	if len(remotes) == 0 {
+		return "", errs.New(errs.KindNotFound, "The current repository has no git remotes to discover its job from")
+	}
+
+	normalized := make([]string, len(remotes))
+	for i, r := range remotes {
+		normalized[i] = git.NormalizeRemote(r)
+	}
+
+	cache, err := readJobCache(jenkinsCtx)
+	if err != nil {
+		return "", err
+	}
+
+	if !refresh {
+		for _, r := range normalized {
+			if job, ok := cache[r]; ok {
+				log.Debug("Found cached job ", job, " for remote ", r)
+				return job, nil
+			}
+		}
+	}
+
+	client, err := jenkins.NewClient(ctx, &jenkinsCtx, streams)
+	if err != nil {
+		return "", err
+	}
+
+	jobs, err := client.ListJobs(ctx, DiscoveryDepth)
+	if err != nil {
+		return "", err
+	}
+
+	for _, j := range jobs {
+		if j.Class != jenkins.MultiBranchProjectClass {
+			continue
+		}
+
+		name := jenkins.FullName(j.Name)
+		log.Debug("Checking branch sources of ", name)
+
+		xml, err := client.GetJobConfig(ctx, name)
+		if err != nil {
+			log.Debug("Skipping ", name, ": ", err)
+			continue
+		}
+
+		for _, r := range normalized {
+			if matchesSource(xml, r) {
+				cache[r] = name
+				if err := cache.save(jenkinsCtx); err != nil {
+					return "", err
+				}
+
+				return name, nil
+			}
+		}
+	}
+
+	return "", errs.New(errs.KindNotFound, "No multibranch job found for remotes %s. Please provide one with --job or set 'job' in %s.", strings.Join(remotes, ", "), ProjectFile)
+}
+
+// matchesSource reports whether a job config declares a branch source for
+// the normalized remote.
+func matchesSource(xml string, remote string) bool {
+	for _, m := range remotePattern.FindAllStringSubmatch(xml, -1) {
+		if git.NormalizeRemote(m[1]) == remote {
+			return true
+		}
+	}
+
+	owner := repoOwnerPattern.FindStringSubmatch(xml)
+	repo := repositoryPattern.FindStringSubmatch(xml)
+	if owner != nil && repo != nil {
+		suffix := strings.ToLower("/" + owner[1] + "/" + strings.TrimSuffix(repo[1], ".git"))
+		return strings.HasSuffix(remote, suffix)
+	}
+
+	return false
+}
@@ -0,0 +1,96 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/ghodss/yaml"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+)
+
+const PresetFile = "presets.yaml"
+
+// Preset is a named set of build parameters. Presets without a job build
+// the job of the current branch.
+type Preset struct {
+	Job        string            `json:"job,omitempty"`
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// Presets maps preset names to presets
+type Presets map[string]Preset
+
+func getPresetFile(ctx config.Context) (string, error) {
+	dir, err := ctx.GetContextDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, PresetFile), nil
+}
+
+// ReadPresets reads the presets saved for the context
+func ReadPresets(ctx config.Context) (Presets, error) {
+	f, err := getPresetFile(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	presets := Presets{}
+
+	y, err := os.ReadFile(f)
+	if os.IsNotExist(err) {
+		return presets, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(y, &presets); err != nil {
+		return nil, errs.New(errs.KindConfig, "Invalid presets file %s: %s", f, err)
+	}
+
+	return presets, nil
+}
+
+// Save writes the presets for the context
+func (p Presets) Save(ctx config.Context) error {
+	f, err := getPresetFile(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(f), 0700); err != nil {
+		return err
+	}
+
+	y, err := yaml.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(f, y, 0600)
+}
+
+// Get returns the named preset
+func (p Presets) Get(name string) (Preset, error) {
+	preset, ok := p[name]
+	if !ok {
+		return Preset{}, errs.New(errs.KindNotFound, "Preset '%s' not found", name)
+	}
+
+	return preset, nil
+}
+
+// Names returns the preset names in order
+func (p Presets) Names() []string {
+	names := make([]string, 0, len(p))
+	for name := range p {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
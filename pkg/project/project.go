@@ -0,0 +1,199 @@
+package project
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+
+	config "github.com/thecodesmith/jenkinsw/pkg/config"
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/git"
+	"github.com/thecodesmith/jenkinsw/pkg/jenkins"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+const ProjectFile = ".jenkinsw.yaml"
+
+const DefaultJenkinsfile = "Jenkinsfile"
+
+// Project holds the repository metadata declared in a .jenkinsw.yaml file
+type Project struct {
+	Context      string            `json:"context,omitempty"`
+	Job          string            `json:"job,omitempty"`
+	Jenkinsfiles []string          `json:"jenkinsfiles,omitempty"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+
+	// File is the path of the project file, empty if none was found
+	File string `json:"-"`
+}
+
+// Find walks up from dir looking for a project file and returns its path,
+// or an empty string if none is found. The home directory is not searched
+// since $HOME/.jenkinsw.yaml holds the global configuration.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	home, _ := os.UserHomeDir()
+
+	for {
+		if dir != home {
+			f := filepath.Join(dir, ProjectFile)
+			if _, err := os.Stat(f); err == nil {
+				return f, nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads the project file for the working directory. An empty project
+// is returned if there is no project file.
+func Load() (Project, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return Project{}, err
+	}
+
+	f, err := Find(wd)
+	if err != nil || f == "" {
+		return Project{}, err
+	}
+
+	return Read(f)
+}
+
+// Read parses the project file at the given path
+func Read(f string) (Project, error) {
+	y, err := os.ReadFile(f)
+	if err != nil {
+		return Project{}, err
+	}
+
+	var p Project
+	if err := yaml.Unmarshal(y, &p); err != nil {
+		return Project{}, errs.New(errs.KindConfig, "Invalid project file %s: %s", f, err)
+	}
+
+	p.File = f
+
+	return p, nil
+}
+
+// Dir returns the directory containing the project file
+func (p Project) Dir() string {
+	if p.File == "" {
+		return ""
+	}
+
+	return filepath.Dir(p.File)
+}
+
+// JenkinsfilePaths returns the declared Jenkinsfiles relative to the project
+// file, or the Jenkinsfile in the current directory if none are declared.
+func (p Project) JenkinsfilePaths() []string {
+	if len(p.Jenkinsfiles) == 0 {
+		return []string{DefaultJenkinsfile}
+	}
+
+	paths := make([]string, len(p.Jenkinsfiles))
+	for i, f := range p.Jenkinsfiles {
+		if filepath.IsAbs(f) {
+			paths[i] = f
+		} else {
+			paths[i] = filepath.Join(p.Dir(), f)
+		}
+	}
+
+	return paths
+}
+
+// Jenkinsfile returns the primary Jenkinsfile of the project
+func (p Project) Jenkinsfile() string {
+	return p.JenkinsfilePaths()[0]
+}
+
+// Apply merges the project settings on top of the configuration
+func (p Project) Apply(cfg *config.Config) error {
+	if p.Context == "" {
+		return nil
+	}
+
+	if !cfg.IsExistingContext(p.Context) {
+		return errs.New(errs.KindConfig, "Context named '%s' from %s not found. Use 'jenkinsw context list' to view available contexts.", p.Context, p.File)
+	}
+
+	cfg.CurrentContext = p.Context
+
+	return nil
+}
+
+// CurrentContext returns the context selected by the project, falling back
+// to the current context of the configuration.
+func (p Project) CurrentContext() (config.Context, error) {
+	cfg, err := config.ReadConfig()
+	if err != nil {
+		return config.Context{}, err
+	}
+
+	if err := p.Apply(&cfg); err != nil {
+		return config.Context{}, err
+	}
+
+	return cfg.GetCurrentContext()
+}
+
+// ResolveJob returns the full name of the multibranch job. The job
+// overrides the project's job, which in turn overrides the job discovered
+// from the git remotes.
+func (p Project) ResolveJob(ctx context.Context, streams *utils.IOStreams, job string) (string, error) {
+	if job != "" {
+		return job, nil
+	}
+
+	if p.Job != "" {
+		return p.Job, nil
+	}
+
+	jenkinsCtx, err := p.CurrentContext()
+	if err != nil {
+		return "", err
+	}
+
+	return DiscoverJob(ctx, streams, jenkinsCtx, false)
+}
+
+// BranchJob returns the full name of the job for a branch of the multibranch
+// project resolved by ResolveJob. The current git branch is used if branch
+// is empty.
+func (p Project) BranchJob(ctx context.Context, streams *utils.IOStreams, job string, branch string) (string, error) {
+	job, err := p.ResolveJob(ctx, streams, job)
+	if err != nil {
+		return "", err
+	}
+
+	branch, err = ResolveBranch(branch)
+	if err != nil {
+		return "", err
+	}
+
+	return jenkins.BranchJobName(job, branch), nil
+}
+
+// ResolveBranch returns the branch, or the current git branch if empty
+func ResolveBranch(branch string) (string, error) {
+	if branch != "" {
+		return branch, nil
+	}
+
+	return git.CurrentBranch()
+}
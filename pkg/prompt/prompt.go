@@ -0,0 +1,182 @@
+package prompt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/thecodesmith/jenkinsw/pkg/errs"
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+// ErrNoInput is returned when the input ends before an answer is given
+var ErrNoInput = errs.New(errs.KindValidation, "no input available")
+
+// Prompter asks questions on the error stream and reads answers from the
+// input stream, one line per answer.
+type Prompter struct {
+	ioStreams *utils.IOStreams
+	reader    *bufio.Reader
+}
+
+func New(streams *utils.IOStreams) *Prompter {
+	return &Prompter{ioStreams: streams, reader: bufio.NewReader(streams.In)}
+}
+
+// IsInteractive reports whether the input stream is a terminal
+func (p *Prompter) IsInteractive() bool {
+	f, ok := p.ioStreams.In.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// Input asks for a value, returning def if the answer is empty
+func (p *Prompter) Input(label string, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.ioStreams.ErrOut, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(p.ioStreams.ErrOut, "%s: ", label)
+	}
+
+	answer, err := p.readLine()
+	if err != nil {
+		return "", err
+	}
+
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// Required asks for a value until a non-empty answer is given
+func (p *Prompter) Required(label string) (string, error) {
+	for {
+		answer, err := p.Input(label, "")
+		if err != nil || answer != "" {
+			return answer, err
+		}
+
+		fmt.Fprintln(p.ioStreams.ErrOut, "A value is required")
+	}
+}
+
+// Confirm asks a yes/no question, returning def if the answer is empty
+func (p *Prompter) Confirm(label string, def bool) (bool, error) {
+	hint := "y/N"
+	if def {
+		hint = "Y/n"
+	}
+
+	for {
+		fmt.Fprintf(p.ioStreams.ErrOut, "%s [%s]: ", label, hint)
+
+		answer, err := p.readLine()
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return def, nil
+		case "y", "yes", "true":
+			return true, nil
+		case "n", "no", "false":
+			return false, nil
+		}
+
+		fmt.Fprintln(p.ioStreams.ErrOut, "Please answer y or n")
+	}
+}
+
+// Select asks for one of the options from a numbered menu. The answer may
+// be the number or the option itself; an empty answer selects def.
+func (p *Prompter) Select(label string, options []string, def string) (string, error) {
+	if len(options) == 0 {
+		return "", fmt.Errorf("No options to select for '%s'", label)
+	}
+
+	fmt.Fprintf(p.ioStreams.ErrOut, "%s:\n", label)
+
+	defIndex := 0
+	for i, o := range options {
+		marker := " "
+		if o == def {
+			marker = "*"
+			defIndex = i
+		}
+		fmt.Fprintf(p.ioStreams.ErrOut, " %s %d) %s\n", marker, i+1, o)
+	}
+
+	for {
+		answer, err := p.Input("Select", strconv.Itoa(defIndex+1))
+		if err != nil {
+			return "", err
+		}
+
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return options[n-1], nil
+		}
+
+		for _, o := range options {
+			if o == answer {
+				return o, nil
+			}
+		}
+
+		fmt.Fprintf(p.ioStreams.ErrOut, "Please select a number between 1 and %d\n", len(options))
+	}
+}
+
+// Password asks for a secret value without echoing it when the input is a
+// terminal. An empty answer returns def.
+func (p *Prompter) Password(label string, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(p.ioStreams.ErrOut, "%s [hidden]: ", label)
+	} else {
+		fmt.Fprintf(p.ioStreams.ErrOut, "%s: ", label)
+	}
+
+	var answer string
+	if p.IsInteractive() {
+		b, err := term.ReadPassword(int(p.ioStreams.In.(*os.File).Fd()))
+		fmt.Fprintln(p.ioStreams.ErrOut)
+		if err != nil {
+			return "", err
+		}
+		answer = strings.TrimSpace(string(b))
+	} else {
+		var err error
+		if answer, err = p.readLine(); err != nil {
+			return "", err
+		}
+	}
+
+	if answer == "" {
+		return def, nil
+	}
+
+	return answer, nil
+}
+
+// readLine reads one line of input without its line ending. A final line
+// without a newline is accepted, but no input at all is an error.
+func (p *Prompter) readLine() (string, error) {
+	line, err := p.reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err == io.EOF {
+		return "", ErrNoInput
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(line), nil
+}
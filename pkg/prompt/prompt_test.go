@@ -0,0 +1,49 @@
+package prompt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/thecodesmith/jenkinsw/pkg/utils"
+)
+
+func TestPrompterWritesToErrOut(t *testing.T) {
+	var out, errOut bytes.Buffer
+	streams := utils.IOStreams{In: strings.NewReader("\nvalue\nmaybe\ny\n2\nsecret\n"), Out: &out, ErrOut: &errOut}
+	p := New(&streams)
+
+	if answer, err := p.Input("Name", "default"); err != nil || answer != "default" {
+		t.Errorf("Input() = %q, %v, want default", answer, err)
+	}
+
+	if answer, err := p.Required("Value"); err != nil || answer != "value" {
+		t.Errorf("Required() = %q, %v, want value", answer, err)
+	}
+
+	if answer, err := p.Confirm("Continue", false); err != nil || !answer {
+		t.Errorf("Confirm() = %v, %v, want true", answer, err)
+	}
+
+	if answer, err := p.Select("Pick", []string{"a", "b"}, "a"); err != nil || answer != "b" {
+		t.Errorf("Select() = %q, %v, want b", answer, err)
+	}
+
+	if answer, err := p.Password("Token", ""); err != nil || answer != "secret" {
+		t.Errorf("Password() = %q, %v, want secret", answer, err)
+	}
+
+	if _, err := p.Input("Name", ""); err != ErrNoInput {
+		t.Errorf("Input() error = %v, want ErrNoInput", err)
+	}
+
+	if out.Len() != 0 {
+		t.Errorf("Out = %q, want empty", out.String())
+	}
+
+	for _, s := range []string{"Name [default]: ", "Please answer y or n", " * 1) a", "   2) b", "Token: "} {
+		if !strings.Contains(errOut.String(), s) {
+			t.Errorf("ErrOut = %q, want it to contain %q", errOut.String(), s)
+		}
+	}
+}
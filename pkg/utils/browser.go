@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// OpenBrowser opens the URL with the first available command in $BROWSER,
+// falling back to the default browser of the platform.
+func OpenBrowser(url string) error {
+	for _, args := range browserCommands(os.Getenv("BROWSER"), url) {
+		path, err := exec.LookPath(args[0])
+		if err != nil {
+			continue
+		}
+
+		return exec.Command(path, args[1:]...).Start()
+	}
+
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+
+	return cmd.Start()
+}
+
+// browserCommands splits $BROWSER into its colon-separated commands for the
+// URL. A %s argument is replaced by the URL, which is appended otherwise.
+func browserCommands(browser string, url string) [][]string {
+	r := strings.NewReplacer("%%", "%", "%s", url)
+
+	var commands [][]string
+	for _, command := range strings.Split(browser, ":") {
+		args := strings.Fields(command)
+		if len(args) == 0 {
+			continue
+		}
+
+		substituted := strings.Contains(command, "%s")
+		for i, arg := range args {
+			args[i] = r.Replace(arg)
+		}
+
+		if !substituted {
+			args = append(args, url)
+		}
+
+		commands = append(commands, args)
+	}
+
+	return commands
+}
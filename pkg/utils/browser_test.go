@@ -0,0 +1,28 @@
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBrowserCommands(t *testing.T) {
+	url := "https://jenkins.example.com/job/app/"
+
+	tests := []struct {
+		browser string
+		want    [][]string
+	}{
+		{"", nil},
+		{"firefox", [][]string{{"firefox", url}}},
+		{"firefox --new-tab", [][]string{{"firefox", "--new-tab", url}}},
+		{"lynx -dump %s", [][]string{{"lynx", "-dump", url}}},
+		{"echo 100%%", [][]string{{"echo", "100%", url}}},
+		{"google-chrome:firefox %s::", [][]string{{"google-chrome", url}, {"firefox", url}}},
+	}
+
+	for _, tt := range tests {
+		if got := browserCommands(tt.browser, url); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("browserCommands(%q) = %q, want %q", tt.browser, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package utils
+
+import "testing"
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"*.jar", "app.jar", true},
+		{"*.jar", "lib/app.jar", false},
+		{"dist/*.tar.gz", "dist/app.tar.gz", true},
+		{"dist/**/*.tar.gz", "dist/app.tar.gz", true},
+		{"dist/**/*.tar.gz", "dist/a/b/app.tar.gz", true},
+		{"dist/**/*.tar.gz", "build/a/app.tar.gz", false},
+		{"**/*.jar", "app.jar", true},
+		{"**/*.jar", "target/lib/app.jar", true},
+		{"**/*.jar", "target/lib/app.war", false},
+		{"target/**", "target", true},
+		{"target/**", "target/app.jar", true},
+		{"target/**", "target/lib/app.jar", true},
+		{"target/**", "dist/app.jar", false},
+		{"**", "any/path/at/all", true},
+		{"**/lib/**", "target/lib/a/app.jar", true},
+		{"**/lib/**", "target/libs/app.jar", false},
+		{"[", "[", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchGlob(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("MatchGlob(%q, %q) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
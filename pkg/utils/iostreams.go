@@ -3,6 +3,9 @@ package utils
 import (
 	"io"
 	"os"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
 type IOStreams struct {
@@ -19,3 +22,29 @@ func NewStdStreams() IOStreams {
 		ErrOut: os.Stderr,
 	}
 }
+
+// CommandStreams returns the streams of a command. Commands inherit the
+// streams set on the root command.
+func CommandStreams(cmd *cobra.Command) IOStreams {
+	return IOStreams{
+		In:     cmd.InOrStdin(),
+		Out:    cmd.OutOrStdout(),
+		ErrOut: cmd.ErrOrStderr(),
+	}
+}
+
+// IsOutputTerminal reports whether the output stream is a terminal
+func (s IOStreams) IsOutputTerminal() bool {
+	f, ok := s.Out.(*os.File)
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
+// ColorEnabled reports whether output should be colored: only on a
+// terminal, and never if NO_COLOR is set (see https://no-color.org).
+func (s IOStreams) ColorEnabled() bool {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return false
+	}
+
+	return s.IsOutputTerminal()
+}